@@ -65,6 +65,7 @@ func (c ChannelsResource) GetByBroadcasterIDs(
 			Resource: resource,
 			Method:   http.MethodGet,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeChannelRead},
 			URLValues: urloptional.Values{
 				"broadcaster_user_id": urloptional.Many(broadcasterIDs),
 			},
@@ -92,6 +93,7 @@ func (c ChannelsResource) UpdateStream(ctx context.Context, input UpdateStreamIn
 			Resource: resource,
 			Method:   http.MethodPatch,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeChannelWrite},
 			Body:     input,
 		},
 	)
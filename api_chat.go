@@ -57,6 +57,7 @@ func (c ChatResource) PostMessage(
 			Resource: resource,
 			Method:   http.MethodPost,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeChatWrite},
 			Body:     input,
 		},
 	)
@@ -49,6 +49,7 @@ func (e EventsResource) GetSubscriptions(ctx context.Context) (Response[[]EventS
 			Resource: resource,
 			Method:   http.MethodGet,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeEventsSubscribe},
 		},
 	)
 
@@ -90,6 +91,7 @@ func (e EventsResource) Subscribe(
 			Resource: resource,
 			Method:   http.MethodPost,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeEventsSubscribe},
 			Body:     input,
 		},
 	)
@@ -121,6 +123,7 @@ func (e EventsResource) Unsubscribe(
 			Resource: resource,
 			Method:   http.MethodDelete,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeEventsSubscribe},
 			URLValues: urloptional.Values{
 				"id": urloptional.Many(input.EventsIDs),
 			},
@@ -2,8 +2,10 @@ package kicksdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/glichtv/kick-sdk/internal/urloptional"
 	"github.com/glichtv/kick-sdk/optional"
@@ -15,6 +17,43 @@ type AccessToken struct {
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"`
 	Scope        string `json:"scope"`
+
+	// ExpiresAt is an absolute time when the token expires. It is calculated from the ExpiresIn once token
+	// is received and preserved when token is encoded to JSON and decoded back.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+func (t *AccessToken) UnmarshalJSON(data []byte) error {
+	type accessToken AccessToken
+
+	if err := json.Unmarshal(data, (*accessToken)(t)); err != nil {
+		return err
+	}
+
+	if t.ExpiresAt.IsZero() && t.ExpiresIn > 0 {
+		t.ExpiresAt = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+
+	return nil
+}
+
+// IsExpired returns true if the token expires within the skew. Token with unknown expiration time
+// is never considered expired.
+func (t AccessToken) IsExpired(skew time.Duration) bool {
+	return isExpired(t.ExpiresAt, skew)
+}
+
+// Scopes returns scopes granted to the token.
+func (t AccessToken) Scopes() ScopeSet {
+	return ParseScopes(t.Scope)
+}
+
+func isExpired(expiresAt time.Time, skew time.Duration) bool {
+	if expiresAt.IsZero() {
+		return false
+	}
+
+	return !time.Now().Add(skew).Before(expiresAt)
 }
 
 type TokenHintType = string
@@ -59,16 +98,34 @@ func (o OAuthResource) AuthorizationURL(input AuthorizationURLInput) string {
 		"scope":                 urloptional.Join(scopes, " "),
 		"state":                 urloptional.Single(input.State),
 		"code_challenge":        urloptional.Single(input.CodeChallenge),
-		"code_challenge_method": urloptional.Single("S256"),
+		"code_challenge_method": urloptional.Single(CodeChallengeMethodS256),
 	}
 
 	return fmt.Sprintf("%s?%s", resource.URL(), values.Encode())
 }
 
+// GrantType is a type of the grant used to issue access token.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc6749#section-4
+type GrantType string
+
+const (
+	GrantTypeAuthorizationCode GrantType = "authorization_code"
+	GrantTypeRefreshToken      GrantType = "refresh_token"
+	GrantTypeClientCredentials GrantType = "client_credentials"
+)
+
 type ExchangeCodeInput struct {
-	Code         string
-	GrantType    string
+	Code string
+	// GrantType is a type of the grant, GrantTypeAuthorizationCode by default.
+	GrantType    GrantType
 	CodeVerifier string
+
+	// Session is an authorization session started with StartAuthorization. If it's set, its code
+	// verifier is used and State is verified against it before the code is exchanged.
+	Session optional.Optional[AuthorizationSession]
+	// State is a state returned to the redirect URI along with the code.
+	State string
 }
 
 // ExchangeCode exchanges the code for a valid AccessToken's that can be used to make authorized
@@ -76,47 +133,61 @@ type ExchangeCodeInput struct {
 //
 // Reference: https://docs.kick.com/getting-started/generating-tokens-oauth2-flow#token-endpoint
 func (o OAuthResource) ExchangeCode(ctx context.Context, input ExchangeCodeInput) (Response[AccessToken], error) {
-	resource := o.client.NewResource(ResourceTypeID, "oauth/token")
+	if session, set := input.Session.Value(); set {
+		if err := session.Verify(input.State); err != nil {
+			return Response[AccessToken]{}, err
+		}
 
-	request := NewRequest[AccessToken](ctx, o.client, RequestOptions{
-		Resource: resource,
-		Method:   http.MethodPost,
-		Body: urloptional.Values{
-			"code":          urloptional.Single(input.Code),
-			"client_id":     urloptional.Single(o.client.credentials.ClientID),
-			"client_secret": urloptional.Single(o.client.credentials.ClientSecret),
-			"redirect_uri":  urloptional.Single(o.client.credentials.RedirectURI),
-			"grant_type":    urloptional.Single(input.GrantType),
-			"code_verifier": urloptional.Single(input.CodeVerifier),
-		},
-	})
+		input.CodeVerifier = session.CodeVerifier
+	}
+
+	if len(input.GrantType) == 0 {
+		input.GrantType = GrantTypeAuthorizationCode
+	}
 
-	return request.Execute()
+	return executeTokenRequest[AccessToken](ctx, o.client, "oauth/token", urloptional.Values{
+		"code":          urloptional.Single(input.Code),
+		"client_id":     urloptional.Single(o.client.credentials.ClientID),
+		"client_secret": urloptional.Single(o.client.credentials.ClientSecret),
+		"redirect_uri":  urloptional.Single(o.client.credentials.RedirectURI),
+		"grant_type":    urloptional.Single(string(input.GrantType)),
+		"code_verifier": urloptional.Single(input.CodeVerifier),
+	})
 }
 
 type RefreshTokenInput struct {
 	RefreshToken string
-	GrantType    string
+	// GrantType is a type of the grant, GrantTypeRefreshToken by default.
+	GrantType GrantType
 }
 
-// RefreshToken refreshes both access and refresh tokens.
+// RefreshToken refreshes both access and refresh tokens. Error matching ErrInvalidGrant is returned if
+// refresh token is revoked or expired, so user must authorize again.
 //
 // Reference: https://docs.kick.com/getting-started/generating-tokens-oauth2-flow#refresh-token-endpoint
 func (o OAuthResource) RefreshToken(ctx context.Context, input RefreshTokenInput) (Response[AccessToken], error) {
-	resource := o.client.NewResource(ResourceTypeID, "oauth/token")
+	if len(input.GrantType) == 0 {
+		input.GrantType = GrantTypeRefreshToken
+	}
 
-	request := NewRequest[AccessToken](ctx, o.client, RequestOptions{
-		Resource: resource,
-		Method:   http.MethodPost,
-		Body: urloptional.Values{
-			"refresh_token": urloptional.Single(input.RefreshToken),
-			"client_id":     urloptional.Single(o.client.credentials.ClientID),
-			"client_secret": urloptional.Single(o.client.credentials.ClientSecret),
-			"grant_type":    urloptional.Single(input.GrantType),
-		},
+	return executeTokenRequest[AccessToken](ctx, o.client, "oauth/token", urloptional.Values{
+		"refresh_token": urloptional.Single(input.RefreshToken),
+		"client_id":     urloptional.Single(o.client.credentials.ClientID),
+		"client_secret": urloptional.Single(o.client.credentials.ClientSecret),
+		"grant_type":    urloptional.Single(string(input.GrantType)),
 	})
+}
 
-	return request.Execute()
+// AppAccessToken issues app access token using client credentials grant. App access tokens are not
+// bound to any user and have no refresh token.
+//
+// Reference: https://docs.kick.com/getting-started/generating-tokens-oauth2-flow#app-access-token
+func (o OAuthResource) AppAccessToken(ctx context.Context) (Response[AccessToken], error) {
+	return executeTokenRequest[AccessToken](ctx, o.client, "oauth/token", urloptional.Values{
+		"client_id":     urloptional.Single(o.client.credentials.ClientID),
+		"client_secret": urloptional.Single(o.client.credentials.ClientSecret),
+		"grant_type":    urloptional.Single(string(GrantTypeClientCredentials)),
+	})
 }
 
 type RevokeTokenInput struct {
@@ -128,16 +199,37 @@ type RevokeTokenInput struct {
 //
 // Reference: https://docs.kick.com/getting-started/generating-tokens-oauth2-flow#revoke-token-endpoint
 func (o OAuthResource) RevokeToken(ctx context.Context, input RevokeTokenInput) (Response[EmptyResponse], error) {
-	resource := o.client.NewResource(ResourceTypeID, "oauth/revoke")
+	return executeTokenRequest[EmptyResponse](ctx, o.client, "oauth/revoke", urloptional.Values{
+		"token":           urloptional.Single(input.Token),
+		"token_hint_type": urloptional.SingleOptional(input.TokenHintType),
+	})
+}
 
-	request := NewRequest[EmptyResponse](ctx, o.client, RequestOptions{
-		Resource: resource,
+// executeTokenRequest sends form-encoded request to the token endpoint of the authorization server and
+// converts unsuccessful responses to the OAuthError.
+func executeTokenRequest[Output any](
+	ctx context.Context,
+	client *Client,
+	path string,
+	values urloptional.Values,
+) (Response[Output], error) {
+	request := NewRequest[Output](ctx, client, RequestOptions{
+		Resource: client.NewResource(ResourceTypeID, path),
 		Method:   http.MethodPost,
-		URLValues: urloptional.Values{
-			"token":           urloptional.Single(input.Token),
-			"token_hint_type": urloptional.SingleOptional(input.TokenHintType),
-		},
+		Body:     values,
 	})
 
-	return request.Execute()
+	response, err := request.Execute()
+
+	// Error body might be not a JSON (e.g. returned by the proxy), but the status code is still enough
+	// to classify the error.
+	if response.ResponseMetadata.StatusCode >= http.StatusMultipleChoices {
+		return response, OAuthError{
+			StatusCode:  response.ResponseMetadata.StatusCode,
+			Code:        response.ResponseMetadata.KickError,
+			Description: response.ResponseMetadata.KickErrorDescription,
+		}
+	}
+
+	return response, err
 }
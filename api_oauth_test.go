@@ -3,8 +3,10 @@ package kicksdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -68,12 +70,15 @@ func TestOAuthResource_ExchangeCode(t *testing.T) {
 			context.Background(),
 			ExchangeCodeInput{
 				Code:         "code",
-				GrantType:    "authorization_code",
+				GrantType:    GrantTypeAuthorizationCode,
 				CodeVerifier: "code-verifier",
 			},
 		)
 		assert.NoError(t, err)
 
+		assert.WithinDuration(t, time.Now().Add(42*time.Second), response.Payload.ExpiresAt, time.Second)
+
+		response.Payload.ExpiresAt = time.Time{}
 		assert.Equal(t, expectedData, response.Payload)
 	})
 }
@@ -94,6 +99,11 @@ func TestOAuthResource_RefreshToken(t *testing.T) {
 		assert.NoError(t, err)
 
 		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != string(GrantTypeRefreshToken) {
+				http.Error(w, "Invalid grant type", http.StatusInternalServerError)
+				return
+			}
+
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write(expectedResponseBytes)
 		})
@@ -102,11 +112,13 @@ func TestOAuthResource_RefreshToken(t *testing.T) {
 			context.Background(),
 			RefreshTokenInput{
 				RefreshToken: "refresh-token",
-				GrantType:    "grant-type",
 			},
 		)
 		assert.NoError(t, err)
 
+		assert.WithinDuration(t, time.Now().Add(42*time.Second), response.Payload.ExpiresAt, time.Second)
+
+		response.Payload.ExpiresAt = time.Time{}
 		assert.Equal(t, expectedData, response.Payload)
 	})
 }
@@ -120,8 +132,8 @@ func TestOAuthResource_RevokeToken(t *testing.T) {
 		}
 
 		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.String() != "/oauth/revoke?token=token" {
-				http.Error(w, "Invalid URL", http.StatusInternalServerError)
+			if err := r.ParseForm(); err != nil || r.URL.RawQuery != "" || r.PostForm.Get("token") != "token" {
+				http.Error(w, "Invalid body", http.StatusInternalServerError)
 				return
 			}
 
@@ -135,3 +147,165 @@ func TestOAuthResource_RevokeToken(t *testing.T) {
 		assert.Equal(t, http.StatusOK, response.ResponseMetadata.StatusCode)
 	})
 }
+
+func TestAccessToken_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Expiration time is calculated on decoding", func(t *testing.T) {
+		var token AccessToken
+
+		err := json.Unmarshal([]byte(`{"access_token": "token", "expires_in": 3600}`), &token)
+		assert.NoError(t, err)
+
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+	})
+
+	t.Run("Token is preserved after round-trip", func(t *testing.T) {
+		token := AccessToken{
+			AccessToken: "token",
+			ExpiresIn:   3600,
+			Scope:       "user:read",
+			ExpiresAt:   time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		tokenBytes, err := json.Marshal(token)
+		assert.NoError(t, err)
+
+		var decodedToken AccessToken
+
+		err = json.Unmarshal(tokenBytes, &decodedToken)
+		assert.NoError(t, err)
+
+		assert.True(t, token.ExpiresAt.Equal(decodedToken.ExpiresAt))
+		assert.Equal(t, token.Scopes(), decodedToken.Scopes())
+	})
+}
+
+func TestAccessToken_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		token    AccessToken
+		skew     time.Duration
+		expected bool
+	}{
+		{
+			name:     "Unknown expiration time",
+			token:    AccessToken{},
+			expected: false,
+		},
+		{
+			name:     "Token is not expired",
+			token:    AccessToken{ExpiresAt: time.Now().Add(time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "Token expires within skew",
+			token:    AccessToken{ExpiresAt: time.Now().Add(time.Minute)},
+			skew:     5 * time.Minute,
+			expected: true,
+		},
+		{
+			name:     "Token is expired",
+			token:    AccessToken{ExpiresAt: time.Now().Add(-time.Minute)},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.token.IsExpired(test.skew))
+		})
+	}
+}
+
+func TestOAuthResource_TokenErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Revoked refresh token", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "revoked"}`))
+		})
+
+		response, err := client.OAuth().RefreshToken(
+			context.Background(),
+			RefreshTokenInput{RefreshToken: "refresh-token"},
+		)
+		assert.ErrorIs(t, err, ErrInvalidGrant)
+		assert.NotErrorIs(t, err, ErrInvalidClient)
+		assert.Equal(t, http.StatusBadRequest, response.ResponseMetadata.StatusCode)
+
+		var oauthErr OAuthError
+
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, "revoked", oauthErr.Description)
+		assert.False(t, oauthErr.Temporary())
+	})
+
+	t.Run("Transient failure", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error": "temporarily_unavailable"}`))
+		})
+
+		_, err := client.OAuth().AppAccessToken(context.Background())
+		assert.ErrorIs(t, err, ErrTemporarilyUnavailable)
+
+		var oauthErr OAuthError
+
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.True(t, oauthErr.Temporary())
+	})
+
+	t.Run("Failure with non-JSON body", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Bad Gateway", http.StatusBadGateway)
+		})
+
+		_, err := client.OAuth().AppAccessToken(context.Background())
+
+		var oauthErr OAuthError
+
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, http.StatusBadGateway, oauthErr.StatusCode)
+		assert.True(t, oauthErr.Temporary())
+	})
+
+	t.Run("Successful response with non-OK status", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{}`))
+		})
+
+		response, err := client.OAuth().RevokeToken(context.Background(), RevokeTokenInput{Token: "token"})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, response.ResponseMetadata.StatusCode)
+	})
+}
+
+func TestOAuthResource_AppAccessToken(t *testing.T) {
+	t.Parallel()
+
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil ||
+			r.PostForm.Get("grant_type") != string(GrantTypeClientCredentials) ||
+			r.PostForm.Get("client_secret") != "client-secret" {
+			http.Error(w, "Invalid body", http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"access_token": "app-token", "expires_in": 3600}`))
+	})
+
+	client.credentials = Credentials{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	}
+
+	response, err := client.OAuth().AppAccessToken(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "app-token", response.Payload.AccessToken)
+}
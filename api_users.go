@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/glichtv/kick-sdk/internal/urloptional"
 )
@@ -24,6 +25,25 @@ type (
 	}
 )
 
+// ExpiresAt returns an absolute time when the token expires.
+func (ti TokenInfo) ExpiresAt() time.Time {
+	if ti.Expires == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(ti.Expires, 0)
+}
+
+// IsExpired returns true if the token expires within the skew.
+func (ti TokenInfo) IsExpired(skew time.Duration) bool {
+	return isExpired(ti.ExpiresAt(), skew)
+}
+
+// Scopes returns scopes granted to the token.
+func (ti TokenInfo) Scopes() ScopeSet {
+	return ParseScopes(ti.Scope)
+}
+
 type UsersResource struct {
 	client *Client
 }
@@ -74,6 +94,7 @@ func (u UsersResource) GetByIDs(ctx context.Context, input GetUsersByIDsInput) (
 			Resource: resource,
 			Method:   http.MethodGet,
 			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeUserRead},
 			URLValues: urloptional.Values{
 				"id": urloptional.Many(usersIDs),
 			},
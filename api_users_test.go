@@ -0,0 +1,33 @@
+package kicksdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Token info with expiration time", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+
+		tokenInfo := TokenInfo{
+			Expires: expiresAt.Unix(),
+			Scope:   "user:read chat:write",
+		}
+
+		assert.True(t, expiresAt.Equal(tokenInfo.ExpiresAt()))
+		assert.False(t, tokenInfo.IsExpired(0))
+		assert.True(t, tokenInfo.IsExpired(time.Hour))
+		assert.Equal(t, NewScopeSet(ScopeUserRead, ScopeChatWrite), tokenInfo.Scopes())
+	})
+
+	t.Run("Token info without expiration time", func(t *testing.T) {
+		tokenInfo := TokenInfo{}
+
+		assert.True(t, tokenInfo.ExpiresAt().IsZero())
+		assert.False(t, tokenInfo.IsExpired(time.Hour))
+	})
+}
@@ -1,6 +1,8 @@
 package kicksdk
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 )
 
@@ -15,6 +17,11 @@ type Client struct {
 
 	tokens      AccessTokens
 	credentials Credentials
+	registry    *CredentialsRegistry
+
+	// enforceScopes enables pre-flight check of the endpoint's required scopes against the
+	// known scopes of the user access token.
+	enforceScopes bool
 }
 
 func NewClient(options ...ClientOption) *Client {
@@ -48,17 +55,59 @@ func (c *Client) AccessTokens() AccessTokens {
 func (c *Client) SetAccessTokens(tokens AccessTokens) {
 	if len(tokens.UserAccessToken) != 0 {
 		c.tokens.UserAccessToken = tokens.UserAccessToken
+		c.tokens.UserScopes = tokens.UserScopes
 	}
 }
 
 func (c *Client) WithAccessTokens(tokens AccessTokens) *Client {
-	client := &Client{
-		httpClient:  c.httpClient,
-		baseURLs:    c.baseURLs,
-		credentials: c.credentials,
-	}
-
+	client := c.withoutAccessTokens()
 	client.SetAccessTokens(tokens)
 
 	return client
 }
+
+// App returns a copy of the client that uses credentials of the application registered with the provided
+// name in the client's CredentialsRegistry. Access tokens are not copied, since they are issued per application.
+func (c *Client) App(name string) (*Client, error) {
+	if c.registry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownApp, name)
+	}
+
+	credentials, exist := c.registry.Get(name)
+	if !exist {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownApp, name)
+	}
+
+	client := c.withoutAccessTokens()
+	client.credentials = credentials
+
+	return client, nil
+}
+
+// withoutAccessTokens returns a copy of the client without access tokens.
+func (c *Client) withoutAccessTokens() *Client {
+	return &Client{
+		httpClient:    c.httpClient,
+		baseURLs:      c.baseURLs,
+		credentials:   c.credentials,
+		registry:      c.registry,
+		enforceScopes: c.enforceScopes,
+	}
+}
+
+// LoadTokenScopes introspects the user access token and stores its granted scopes, so they can be
+// used for the scopes enforcement.
+func (c *Client) LoadTokenScopes(ctx context.Context) error {
+	response, err := c.Users().IntrospectToken(ctx)
+	if err != nil {
+		return fmt.Errorf("introspect token: %w", err)
+	}
+
+	if response.ResponseMetadata.StatusCode != http.StatusOK {
+		return fmt.Errorf("introspect token: unexpected status code %d", response.ResponseMetadata.StatusCode)
+	}
+
+	c.tokens.UserScopes = response.Payload.Scopes()
+
+	return nil
+}
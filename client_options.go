@@ -14,12 +14,29 @@ func WithCredentials(credentials Credentials) ClientOption {
 	}
 }
 
+// WithCredentialsRegistry sets registry of the applications' credentials, so the client for a specific
+// application can be obtained with Client.App.
+func WithCredentialsRegistry(registry *CredentialsRegistry) ClientOption {
+	return func(client *Client) {
+		client.registry = registry
+	}
+}
+
 func WithAccessTokens(tokens AccessTokens) ClientOption {
 	return func(client *Client) {
 		client.tokens = tokens
 	}
 }
 
+// WithScopesEnforcement enables pre-flight check of the scopes required by endpoints. Requests
+// that require scopes not granted to the user access token fail with the MissingScopesError
+// without being sent. Check is skipped when the token's scopes are unknown.
+func WithScopesEnforcement() ClientOption {
+	return func(client *Client) {
+		client.enforceScopes = true
+	}
+}
+
 type BaseURLs struct {
 	IDBaseURL  string
 	APIBaseURL string
@@ -1,6 +1,7 @@
 package kicksdk
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -61,3 +62,31 @@ func TestClient_WithAccessTokens(t *testing.T) {
 
 	assert.Equal(t, client, clientCopy)
 }
+
+func TestClient_LoadTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Successful load", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data": {"active": true, "scope": "user:read chat:write"}}`))
+		})
+
+		err := client.LoadTokenScopes(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, NewScopeSet(ScopeUserRead, ScopeChatWrite), client.AccessTokens().UserScopes)
+	})
+
+	t.Run("Unsuccessful load", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Unauthorized"}`))
+		})
+
+		err := client.LoadTokenScopes(context.Background())
+		assert.Error(t, err)
+
+		assert.Nil(t, client.AccessTokens().UserScopes)
+	})
+}
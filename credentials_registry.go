@@ -0,0 +1,55 @@
+package kicksdk
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+var ErrUnknownApp = errors.New("application is not registered")
+
+// CredentialsRegistry is a concurrency-safe registry of the credentials of multiple Kick applications
+// used in the same process, keyed by application name.
+type CredentialsRegistry struct {
+	credentials       map[string]Credentials
+	credentialsLocker sync.RWMutex
+}
+
+func NewCredentialsRegistry() *CredentialsRegistry {
+	return &CredentialsRegistry{
+		credentials: make(map[string]Credentials),
+	}
+}
+
+// Register registers credentials of the application with the provided name, replacing the previous ones.
+func (cr *CredentialsRegistry) Register(name string, credentials Credentials) {
+	cr.credentialsLocker.Lock()
+	defer cr.credentialsLocker.Unlock()
+
+	cr.credentials[name] = credentials
+}
+
+func (cr *CredentialsRegistry) Get(name string) (Credentials, bool) {
+	cr.credentialsLocker.RLock()
+	defer cr.credentialsLocker.RUnlock()
+
+	credentials, exist := cr.credentials[name]
+
+	return credentials, exist
+}
+
+// Apps returns names of the registered applications sorted alphabetically.
+func (cr *CredentialsRegistry) Apps() []string {
+	cr.credentialsLocker.RLock()
+	defer cr.credentialsLocker.RUnlock()
+
+	apps := make([]string, 0, len(cr.credentials))
+
+	for name := range cr.credentials {
+		apps = append(apps, name)
+	}
+
+	slices.Sort(apps)
+
+	return apps
+}
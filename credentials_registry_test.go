@@ -0,0 +1,57 @@
+package kicksdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := NewCredentialsRegistry()
+	registry.Register("dashboard", Credentials{ClientID: "dashboard-client-id"})
+	registry.Register("bot", Credentials{ClientID: "bot-client-id"})
+
+	credentials, exist := registry.Get("bot")
+	assert.True(t, exist)
+	assert.Equal(t, "bot-client-id", credentials.ClientID)
+
+	_, exist = registry.Get("unknown")
+	assert.False(t, exist)
+
+	assert.Equal(t, []string{"bot", "dashboard"}, registry.Apps())
+}
+
+func TestClient_App(t *testing.T) {
+	t.Parallel()
+
+	registry := NewCredentialsRegistry()
+	registry.Register("bot", Credentials{ClientID: "bot-client-id", ClientSecret: "bot-client-secret"})
+
+	client := NewClient(
+		WithCredentialsRegistry(registry),
+		WithCredentials(Credentials{ClientID: "default-client-id"}),
+		WithAccessTokens(AccessTokens{UserAccessToken: "token"}),
+	)
+
+	t.Run("Registered application", func(t *testing.T) {
+		appClient, err := client.App("bot")
+		assert.NoError(t, err)
+
+		assert.Equal(t, "bot-client-id", appClient.Credentials().ClientID)
+		assert.Equal(t, AccessTokens{}, appClient.AccessTokens())
+		assert.Equal(t, "default-client-id", client.Credentials().ClientID)
+
+		authURL := appClient.OAuth().AuthorizationURL(AuthorizationURLInput{})
+		assert.Contains(t, authURL, "client_id=bot-client-id")
+	})
+
+	t.Run("Unknown application", func(t *testing.T) {
+		_, err := client.App("unknown")
+		assert.ErrorIs(t, err, ErrUnknownApp)
+
+		_, err = NewClient().App("bot")
+		assert.ErrorIs(t, err, ErrUnknownApp)
+	})
+}
@@ -77,3 +77,14 @@ type (
 		EndedAt     time.Time   `json:"ended_at"`
 	}
 )
+
+// Versioned payloads of the events, which must be used with On and OnContext to register callbacks for the
+// specific version. Payloads without version are the first versions of the events.
+type (
+	EventChatMessageV1                = EventChatMessage
+	EventChannelFollowV1              = EventChannelFollow
+	EventChannelSubscriptionRenewalV1 = EventChannelSubscriptionRenewal
+	EventChannelSubscriptionGiftsV1   = EventChannelSubscriptionGifts
+	EventChannelSubscriptionCreatedV1 = EventChannelSubscriptionCreated
+	EventLivestreamStatusUpdatedV1    = EventLivestreamStatusUpdated
+)
@@ -0,0 +1,198 @@
+package kicksdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+const defaultWorkersCount = 1
+
+var (
+	ErrEventsQueueFull = errors.New("events queue is full")
+	ErrHandlerShutdown = errors.New("events handler is shut down")
+
+	errUnknownOverflowPolicy = errors.New("unknown overflow policy")
+)
+
+// OverflowPolicy defines what happens with the event when the queue of the worker pool is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks request until there is a space in the queue or the request is canceled.
+	OverflowBlock OverflowPolicy = iota + 1
+	// OverflowDrop drops the event, but responds with 200 status code, so it's never redelivered.
+	OverflowDrop
+	// OverflowReject responds with 503 status code, so Kick redelivers the event later.
+	OverflowReject
+)
+
+// eventTask is an accepted event waiting for its callback to be called.
+type eventTask struct {
+	ctx      context.Context
+	header   WebhookEventHeader
+	key      string
+	dispatch eventDispatch
+}
+
+func (et eventTask) run() {
+	_ = et.dispatch(et.ctx)
+}
+
+// eventsDispatcher runs callbacks of the events asynchronously. Context of the task is already detached
+// from the request's cancellation.
+type eventsDispatcher interface {
+	Dispatch(ctx context.Context, task eventTask) error
+	// Shutdown stops accepting events and waits until dispatched ones are processed.
+	Shutdown(ctx context.Context) error
+}
+
+// goroutineDispatcher runs every callback in its own goroutine.
+type goroutineDispatcher struct {
+	wg           sync.WaitGroup
+	closed       bool
+	closedLocker sync.RWMutex
+}
+
+func newGoroutineDispatcher() *goroutineDispatcher {
+	return &goroutineDispatcher{}
+}
+
+func (gd *goroutineDispatcher) Dispatch(_ context.Context, task eventTask) error {
+	gd.closedLocker.RLock()
+	defer gd.closedLocker.RUnlock()
+
+	if gd.closed {
+		return ErrHandlerShutdown
+	}
+
+	gd.wg.Add(1)
+
+	go func() {
+		defer gd.wg.Done()
+
+		task.run()
+	}()
+
+	return nil
+}
+
+func (gd *goroutineDispatcher) Shutdown(ctx context.Context) error {
+	gd.closedLocker.Lock()
+	gd.closed = true
+	gd.closedLocker.Unlock()
+
+	return waitGroupWithContext(ctx, &gd.wg)
+}
+
+// workerPoolConfig is a configuration of the poolDispatcher set with WithWorkerPool.
+type workerPoolConfig struct {
+	workers   int
+	queueSize int
+	policy    OverflowPolicy
+}
+
+// poolDispatcher runs callbacks on the fixed number of workers reading from the bounded queue.
+type poolDispatcher struct {
+	queue  chan eventTask
+	policy OverflowPolicy
+
+	wg           sync.WaitGroup
+	closed       bool
+	closedLocker sync.RWMutex
+}
+
+func newPoolDispatcher(workers, queueSize int, policy OverflowPolicy) *poolDispatcher {
+	if workers <= 0 {
+		workers = defaultWorkersCount
+	}
+
+	if policy == 0 {
+		policy = OverflowBlock
+	}
+
+	pd := &poolDispatcher{
+		queue:  make(chan eventTask, max(queueSize, 0)),
+		policy: policy,
+	}
+
+	pd.wg.Add(workers)
+
+	for range workers {
+		go pd.work()
+	}
+
+	return pd
+}
+
+func (pd *poolDispatcher) Dispatch(ctx context.Context, task eventTask) error {
+	// Read lock is held while the task is sent, so the queue isn't closed until all the senders are done.
+	pd.closedLocker.RLock()
+	defer pd.closedLocker.RUnlock()
+
+	if pd.closed {
+		return ErrHandlerShutdown
+	}
+
+	switch pd.policy {
+	case OverflowBlock:
+		select {
+		case pd.queue <- task:
+			return nil
+		case <-ctx.Done():
+			return ErrEventsQueueFull
+		}
+	case OverflowDrop, OverflowReject:
+		select {
+		case pd.queue <- task:
+			return nil
+		default:
+		}
+
+		if pd.policy == OverflowDrop {
+			return nil
+		}
+
+		return ErrEventsQueueFull
+	default:
+		return errUnknownOverflowPolicy
+	}
+}
+
+func (pd *poolDispatcher) Shutdown(ctx context.Context) error {
+	pd.closedLocker.Lock()
+
+	if !pd.closed {
+		pd.closed = true
+		close(pd.queue)
+	}
+
+	pd.closedLocker.Unlock()
+
+	return waitGroupWithContext(ctx, &pd.wg)
+}
+
+func (pd *poolDispatcher) work() {
+	defer pd.wg.Done()
+
+	for task := range pd.queue {
+		task.run()
+	}
+}
+
+// waitGroupWithContext waits for the WaitGroup until the context is done.
+func waitGroupWithContext(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
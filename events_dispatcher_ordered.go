@@ -0,0 +1,349 @@
+package kicksdk
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOrderedShards = 16
+	// defaultReorderBufferSize is a maximum number of events held by the reorder buffer of every worker if
+	// the queue size is not set.
+	defaultReorderBufferSize = 1024
+)
+
+type (
+	// EventKeyFunc returns key of the event. Callbacks of the events with the same key are called one by one,
+	// in order of their arrival. Body must not be retained after the function returns.
+	EventKeyFunc func(header WebhookEventHeader, body []byte) string
+
+	OrderedDelivery struct {
+		// Key returns key that events are ordered by, BroadcasterEventKey by default.
+		Key EventKeyFunc
+		// Shards is a number of workers that events are distributed among by their keys, 16 by default. Events
+		// with different keys are processed in parallel, unless they share the same worker.
+		Shards int
+		// QueueSize is a size of the queue of every worker. It also limits number of events held by the reorder
+		// buffer of every worker, which is 1024 if it's zero.
+		QueueSize int
+		// Policy defines what happens with the event when the queue of its worker is full, OverflowBlock
+		// by default.
+		Policy OverflowPolicy
+		// ReorderWindow is a time that events are held for to be reordered by Kick-Event-Message-Timestamp
+		// before their callbacks are called. Reordering is disabled if it's zero.
+		ReorderWindow time.Duration
+	}
+)
+
+// BroadcasterEventKey returns user ID of the event's broadcaster, or empty string if the event has no
+// broadcaster.
+func BroadcasterEventKey(_ WebhookEventHeader, body []byte) string {
+	var event struct {
+		Broadcaster struct {
+			UserID int `json:"user_id"`
+		} `json:"broadcaster"`
+	}
+
+	if err := json.Unmarshal(body, &event); err != nil || event.Broadcaster.UserID == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(event.Broadcaster.UserID)
+}
+
+// orderedDispatcher distributes events among single-worker shards by their keys, so events with the same key
+// are processed sequentially.
+type orderedDispatcher struct {
+	shards  []eventsDispatcher
+	buffers []*reorderBuffer
+}
+
+func newOrderedDispatcher(config OrderedDelivery) *orderedDispatcher {
+	if config.Shards <= 0 {
+		config.Shards = defaultOrderedShards
+	}
+
+	if config.Policy == 0 {
+		config.Policy = OverflowBlock
+	}
+
+	od := &orderedDispatcher{
+		shards: make([]eventsDispatcher, config.Shards),
+	}
+
+	// Overflow policy is applied by the reorder buffers, so released events always wait for the worker.
+	shardPolicy := config.Policy
+	bufferSize := config.QueueSize
+
+	if bufferSize <= 0 {
+		bufferSize = defaultReorderBufferSize
+	}
+
+	if config.ReorderWindow > 0 {
+		shardPolicy = OverflowBlock
+		od.buffers = make([]*reorderBuffer, config.Shards)
+	}
+
+	for i := range od.shards {
+		od.shards[i] = newPoolDispatcher(1, config.QueueSize, shardPolicy)
+
+		if od.buffers != nil {
+			od.buffers[i] = newReorderBuffer(od.shards[i], config.ReorderWindow, bufferSize, config.Policy)
+		}
+	}
+
+	return od
+}
+
+func (od *orderedDispatcher) Dispatch(ctx context.Context, task eventTask) error {
+	shard := shardIndex(task.key, len(od.shards))
+
+	if od.buffers != nil {
+		return od.buffers[shard].Push(ctx, task)
+	}
+
+	return od.shards[shard].Dispatch(ctx, task)
+}
+
+func (od *orderedDispatcher) Shutdown(ctx context.Context) error {
+	for _, buffer := range od.buffers {
+		if err := buffer.Close(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, shard := range od.shards {
+		if err := shard.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func shardIndex(key string, shards int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+
+	return int(hash.Sum32() % uint32(shards))
+}
+
+type (
+	reorderItem struct {
+		task      eventTask
+		timestamp time.Time
+		sequence  uint64
+	}
+
+	// reorderHeap is a min-heap of the events ordered by their message timestamps, and then by arrival.
+	reorderHeap []reorderItem
+)
+
+func (rh reorderHeap) Len() int { return len(rh) }
+
+func (rh reorderHeap) Less(i, j int) bool {
+	if rh[i].timestamp.Equal(rh[j].timestamp) {
+		return rh[i].sequence < rh[j].sequence
+	}
+
+	return rh[i].timestamp.Before(rh[j].timestamp)
+}
+
+func (rh reorderHeap) Swap(i, j int) { rh[i], rh[j] = rh[j], rh[i] }
+
+func (rh *reorderHeap) Push(item any) { *rh = append(*rh, item.(reorderItem)) }
+
+func (rh *reorderHeap) Pop() any {
+	old := *rh
+	item := old[len(old)-1]
+	*rh = old[:len(old)-1]
+
+	return item
+}
+
+// reorderBuffer holds every event for the window and releases them to the target dispatcher in order of their
+// message timestamps, so events delivered slightly out of order are processed in the right order.
+type reorderBuffer struct {
+	target   eventsDispatcher
+	window   time.Duration
+	capacity int
+	policy   OverflowPolicy
+
+	locker    sync.Mutex
+	events    reorderHeap
+	deadlines []time.Time
+	sequence  uint64
+	closed    bool
+	// released is closed and replaced once events are released or the buffer is closed, so blocked pushes
+	// can retry.
+	released chan struct{}
+
+	wake chan struct{}
+	done chan struct{}
+}
+
+func newReorderBuffer(
+	target eventsDispatcher,
+	window time.Duration,
+	capacity int,
+	policy OverflowPolicy,
+) *reorderBuffer {
+	rb := &reorderBuffer{
+		target:   target,
+		window:   window,
+		capacity: capacity,
+		policy:   policy,
+		released: make(chan struct{}),
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	go rb.run()
+
+	return rb
+}
+
+func (rb *reorderBuffer) Push(ctx context.Context, task eventTask) error {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	for !rb.closed && len(rb.events) >= rb.capacity {
+		switch rb.policy {
+		case OverflowDrop:
+			return nil
+		case OverflowReject:
+			return ErrEventsQueueFull
+		case OverflowBlock:
+			released := rb.released
+
+			rb.locker.Unlock()
+
+			select {
+			case <-released:
+				rb.locker.Lock()
+			case <-ctx.Done():
+				rb.locker.Lock()
+				return ctx.Err()
+			}
+		default:
+			return errUnknownOverflowPolicy
+		}
+	}
+
+	if rb.closed {
+		return ErrHandlerShutdown
+	}
+
+	now := time.Now()
+
+	// Events with malformed timestamps are ordered by their arrival.
+	timestamp, err := time.Parse(time.RFC3339Nano, task.header.MessageTimestamp)
+	if err != nil {
+		timestamp = now
+	}
+
+	rb.sequence++
+	heap.Push(&rb.events, reorderItem{task: task, timestamp: timestamp, sequence: rb.sequence})
+	rb.deadlines = append(rb.deadlines, now.Add(rb.window))
+
+	rb.notify()
+
+	return nil
+}
+
+// Close releases all the held events and stops the buffer.
+func (rb *reorderBuffer) Close(ctx context.Context) error {
+	rb.locker.Lock()
+	rb.closed = true
+	rb.notifyReleased()
+	rb.locker.Unlock()
+
+	rb.notify()
+
+	select {
+	case <-rb.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rb *reorderBuffer) notify() {
+	select {
+	case rb.wake <- struct{}{}:
+	default:
+	}
+}
+
+// notifyReleased wakes up blocked pushes. Buffer must be locked.
+func (rb *reorderBuffer) notifyReleased() {
+	close(rb.released)
+	rb.released = make(chan struct{})
+}
+
+func (rb *reorderBuffer) run() {
+	defer close(rb.done)
+
+	timer := time.NewTimer(rb.window)
+	defer timer.Stop()
+
+	for {
+		ready, next, closed := rb.release(time.Now())
+
+		for _, task := range ready {
+			_ = rb.target.Dispatch(context.Background(), task)
+		}
+
+		if closed {
+			return
+		}
+
+		timer.Reset(next)
+
+		select {
+		case <-rb.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// release pops events which deadlines are passed (or all of them if the buffer is closed), and returns delay
+// until the next deadline.
+func (rb *reorderBuffer) release(now time.Time) ([]eventTask, time.Duration, bool) {
+	rb.locker.Lock()
+	defer rb.locker.Unlock()
+
+	// Deadlines are ordered, since every event is held for the same window since its arrival.
+	count := len(rb.deadlines)
+	if !rb.closed {
+		count = 0
+
+		for count < len(rb.deadlines) && !now.Before(rb.deadlines[count]) {
+			count++
+		}
+	}
+
+	rb.deadlines = rb.deadlines[count:]
+
+	ready := make([]eventTask, 0, count)
+
+	for range count {
+		ready = append(ready, heap.Pop(&rb.events).(reorderItem).task)
+	}
+
+	if count != 0 {
+		rb.notifyReleased()
+	}
+
+	next := rb.window
+	if len(rb.deadlines) != 0 {
+		next = rb.deadlines[0].Sub(now)
+	}
+
+	return ready, next, rb.closed
+}
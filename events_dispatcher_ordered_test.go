@@ -0,0 +1,216 @@
+package kicksdk
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type orderRecorder struct {
+	locker sync.Mutex
+	order  []string
+}
+
+func (or *orderRecorder) task(key, name, timestamp string) eventTask {
+	return eventTask{
+		ctx:    context.Background(),
+		header: WebhookEventHeader{MessageID: name, MessageTimestamp: timestamp},
+		key:    key,
+		dispatch: func(context.Context) error {
+			// Delay gives unordered delivery a chance to run callbacks concurrently.
+			time.Sleep(time.Millisecond)
+
+			or.locker.Lock()
+			defer or.locker.Unlock()
+
+			or.order = append(or.order, name)
+
+			return nil
+		},
+	}
+}
+
+func (or *orderRecorder) recorded() []string {
+	or.locker.Lock()
+	defer or.locker.Unlock()
+
+	return append([]string(nil), or.order...)
+}
+
+func TestBroadcasterEventKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "123", BroadcasterEventKey(WebhookEventHeader{}, []byte(`{"broadcaster": {"user_id": 123}}`)))
+	assert.Equal(t, "", BroadcasterEventKey(WebhookEventHeader{}, []byte(`{"message_id": "id"}`)))
+	assert.Equal(t, "", BroadcasterEventKey(WebhookEventHeader{}, []byte(`{`)))
+}
+
+func TestWithOrderedDelivery(t *testing.T) {
+	t.Parallel()
+
+	handler := NewWebhookEventsHandler(WithOrderedDelivery(OrderedDelivery{}))
+
+	assert.IsType(t, &orderedDispatcher{}, handler.dispatcher)
+	assert.Equal(t, "123", handler.eventKey(WebhookEventHeader{}, []byte(`{"broadcaster": {"user_id": 123}}`)))
+	assert.NoError(t, handler.Shutdown(context.Background()))
+
+	t.Run("Precedence over worker pool", func(t *testing.T) {
+		for _, options := range [][]EventsHandlerOption{
+			{WithWorkerPool(4, 10, OverflowBlock), WithOrderedDelivery(OrderedDelivery{})},
+			{WithOrderedDelivery(OrderedDelivery{}), WithWorkerPool(4, 10, OverflowBlock)},
+		} {
+			handler := NewWebhookEventsHandler(options...)
+
+			assert.IsType(t, &orderedDispatcher{}, handler.dispatcher)
+			assert.NoError(t, handler.Shutdown(context.Background()))
+		}
+	})
+}
+
+func TestOrderedDispatcher_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Events with the same key", func(t *testing.T) {
+		var (
+			dispatcher = newOrderedDispatcher(OrderedDelivery{QueueSize: 100})
+			recorder   = new(orderRecorder)
+			expected   = make([]string, 0, 20)
+		)
+
+		for i := range 20 {
+			name := strconv.Itoa(i)
+			expected = append(expected, name)
+
+			assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("broadcaster", name, "")))
+		}
+
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+		assert.Equal(t, expected, recorder.recorded())
+	})
+
+	t.Run("Events with different keys", func(t *testing.T) {
+		var (
+			dispatcher = newOrderedDispatcher(OrderedDelivery{Shards: 2, QueueSize: 10})
+			release    = make(chan struct{})
+			done       = make(chan struct{})
+		)
+
+		// Keys are picked so they are processed by different shards.
+		blockedKey, freeKey := "0", "1"
+		for shardIndex(freeKey, 2) == shardIndex(blockedKey, 2) {
+			freeKey += "1"
+		}
+
+		blocked := eventTask{ctx: context.Background(), key: blockedKey, dispatch: func(context.Context) error {
+			<-release
+			return nil
+		}}
+		free := eventTask{ctx: context.Background(), key: freeKey, dispatch: func(context.Context) error {
+			close(done)
+			return nil
+		}}
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), blocked))
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), free))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("event with another key is blocked")
+		}
+
+		close(release)
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+	})
+
+	t.Run("Reorder window", func(t *testing.T) {
+		var (
+			dispatcher = newOrderedDispatcher(OrderedDelivery{QueueSize: 10, ReorderWindow: 50 * time.Millisecond})
+			recorder   = new(orderRecorder)
+		)
+
+		for _, event := range []struct{ name, timestamp string }{
+			{name: "ended", timestamp: "2025-01-01T00:00:03Z"},
+			{name: "started", timestamp: "2025-01-01T00:00:01Z"},
+			{name: "updated", timestamp: "2025-01-01T00:00:02Z"},
+		} {
+			task := recorder.task("broadcaster", event.name, event.timestamp)
+			assert.NoError(t, dispatcher.Dispatch(context.Background(), task))
+		}
+
+		assert.Eventually(t, func() bool {
+			return len(recorder.recorded()) == 3
+		}, time.Second, 10*time.Millisecond)
+
+		assert.Equal(t, []string{"started", "updated", "ended"}, recorder.recorded())
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+	})
+
+	t.Run("Shutdown releases held events", func(t *testing.T) {
+		var (
+			dispatcher = newOrderedDispatcher(OrderedDelivery{ReorderWindow: time.Hour})
+			recorder   = new(orderRecorder)
+		)
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("", "b", "2025-01-01T00:00:02Z")))
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("", "a", "2025-01-01T00:00:01Z")))
+
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+		assert.Equal(t, []string{"a", "b"}, recorder.recorded())
+
+		err := dispatcher.Dispatch(context.Background(), recorder.task("", "c", ""))
+		assert.ErrorIs(t, err, ErrHandlerShutdown)
+	})
+
+	t.Run("Full reorder buffer", func(t *testing.T) {
+		dispatcher := newOrderedDispatcher(OrderedDelivery{
+			Shards:        1,
+			QueueSize:     1,
+			Policy:        OverflowReject,
+			ReorderWindow: time.Hour,
+		})
+
+		recorder := new(orderRecorder)
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("", "a", "")))
+
+		err := dispatcher.Dispatch(context.Background(), recorder.task("", "b", ""))
+		assert.ErrorIs(t, err, ErrEventsQueueFull)
+
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+	})
+
+	t.Run("Blocking full reorder buffer", func(t *testing.T) {
+		var (
+			dispatcher = newOrderedDispatcher(OrderedDelivery{
+				Shards:        1,
+				QueueSize:     1,
+				Policy:        OverflowBlock,
+				ReorderWindow: 50 * time.Millisecond,
+			})
+			recorder = new(orderRecorder)
+		)
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("", "a", "")))
+
+		// Event waits for the space in the buffer only until its request is canceled.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := dispatcher.Dispatch(ctx, recorder.task("", "b", ""))
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// Event is accepted once the held one is released.
+		started := time.Now()
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), recorder.task("", "c", "")))
+		assert.GreaterOrEqual(t, time.Since(started), 20*time.Millisecond)
+
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+		assert.Equal(t, []string{"a", "c"}, recorder.recorded())
+	})
+}
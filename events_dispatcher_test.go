@@ -0,0 +1,221 @@
+package kicksdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingTask returns task that blocks until the release channel is closed and counts finished calls.
+func blockingTask(release <-chan struct{}, finished *atomic.Int32) eventTask {
+	return eventTask{
+		ctx: context.Background(),
+		dispatch: func(context.Context) error {
+			<-release
+			finished.Add(1)
+
+			return nil
+		},
+	}
+}
+
+func TestPoolDispatcher_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	// Single worker is busy and single slot of the queue is taken, so the next event overflows.
+	overflow := func(t *testing.T, policy OverflowPolicy, timeout time.Duration) (int32, error) {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var (
+			dispatcher = newPoolDispatcher(1, 1, policy)
+			release    = make(chan struct{})
+			started    = make(chan struct{})
+			finished   atomic.Int32
+		)
+
+		first := blockingTask(release, &finished)
+		first.dispatch = func(ctx context.Context) error {
+			close(started)
+			return blockingTask(release, &finished).dispatch(ctx)
+		}
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), first))
+
+		<-started
+
+		assert.NoError(t, dispatcher.Dispatch(context.Background(), blockingTask(release, &finished)))
+
+		err := dispatcher.Dispatch(ctx, blockingTask(release, &finished))
+
+		close(release)
+		assert.NoError(t, dispatcher.Shutdown(context.Background()))
+
+		return finished.Load(), err
+	}
+
+	t.Run("Block", func(t *testing.T) {
+		finished, err := overflow(t, OverflowBlock, 50*time.Millisecond)
+		assert.ErrorIs(t, err, ErrEventsQueueFull)
+		assert.Equal(t, int32(2), finished)
+	})
+
+	t.Run("Zero policy blocks", func(t *testing.T) {
+		finished, err := overflow(t, 0, 50*time.Millisecond)
+		assert.ErrorIs(t, err, ErrEventsQueueFull)
+		assert.Equal(t, int32(2), finished)
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		finished, err := overflow(t, OverflowDrop, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), finished)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		finished, err := overflow(t, OverflowReject, time.Second)
+		assert.ErrorIs(t, err, ErrEventsQueueFull)
+		assert.Equal(t, int32(2), finished)
+	})
+}
+
+func TestEventsDispatcher_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	dispatchers := map[string]eventsDispatcher{
+		"Goroutines":  newGoroutineDispatcher(),
+		"Worker pool": newPoolDispatcher(2, 10, OverflowBlock),
+	}
+
+	for name, dispatcher := range dispatchers {
+		t.Run(name, func(t *testing.T) {
+			var (
+				release  = make(chan struct{})
+				finished atomic.Int32
+			)
+
+			for range 5 {
+				assert.NoError(t, dispatcher.Dispatch(context.Background(), blockingTask(release, &finished)))
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			assert.ErrorIs(t, dispatcher.Shutdown(ctx), context.DeadlineExceeded)
+
+			err := dispatcher.Dispatch(context.Background(), blockingTask(release, &finished))
+			assert.ErrorIs(t, err, ErrHandlerShutdown)
+
+			close(release)
+
+			assert.NoError(t, dispatcher.Shutdown(context.Background()))
+			assert.Equal(t, int32(5), finished.Load())
+		})
+	}
+}
+
+func TestWebhookEventsHandler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracker = NewMapEventsTracker()
+		handler = NewWebhookEventsHandler(
+			WithDisabledEventsVerification(),
+			WithEventsTracker(tracker),
+			WithWorkerPool(1, 1, OverflowReject),
+		)
+		calls atomic.Int32
+	)
+
+	handler.OnChatMessage(func(WebhookEventHeader, EventChatMessage) {
+		calls.Add(1)
+	})
+
+	serve := func(messageID string) *httptest.ResponseRecorder {
+		var (
+			recorder = httptest.NewRecorder()
+			header   = mockEventHeader
+		)
+
+		header.MessageID = messageID
+		request := newMockEventRequest(strings.NewReader(`{}`), header)
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusOK, serve("first-message-id").Code)
+	assert.NoError(t, handler.Shutdown(context.Background()))
+	assert.Equal(t, int32(1), calls.Load())
+
+	recorder := serve("second-message-id")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "Cannot accept event\n", recorder.Body.String())
+
+	// Rejected event is not tracked, so its redelivery is not considered duplicate.
+	_, tracked := tracker.events["second-message-id"]
+	assert.False(t, tracked)
+}
+
+func TestWebhookEventsHandler_SynchronousShutdown(t *testing.T) {
+	t.Parallel()
+
+	var (
+		handler = NewWebhookEventsHandler(
+			WithDisabledEventsVerification(),
+			WithEventsTracker(NewMapEventsTracker()),
+			WithSynchronousCallbacks(),
+		)
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+		finished atomic.Int32
+	)
+
+	handler.OnChatMessage(func(WebhookEventHeader, EventChatMessage) {
+		close(started)
+		<-release
+		finished.Add(1)
+	})
+
+	serve := func(messageID string) int {
+		var (
+			recorder = httptest.NewRecorder()
+			header   = mockEventHeader
+		)
+
+		header.MessageID = messageID
+		handler.ServeHTTP(recorder, newMockEventRequest(strings.NewReader(`{}`), header))
+
+		return recorder.Code
+	}
+
+	codes := make(chan int, 1)
+
+	go func() {
+		codes <- serve("first-message-id")
+	}()
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Shutdown waits for the callback that is called synchronously.
+	assert.ErrorIs(t, handler.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Equal(t, http.StatusServiceUnavailable, serve("second-message-id"))
+
+	close(release)
+
+	assert.NoError(t, handler.Shutdown(context.Background()))
+	assert.Equal(t, int32(1), finished.Load())
+	assert.Equal(t, http.StatusOK, <-codes)
+}
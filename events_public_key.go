@@ -0,0 +1,186 @@
+package kicksdk
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/glichtv/kick-sdk/internal/publickey"
+)
+
+const (
+	defaultPublicKeyRefreshInterval = time.Hour
+	// publicKeyRefetchCooldown limits how often the key can be fetched out of schedule (after failed fetch
+	// or signature verification), so forged requests can't be used to flood Kick's API.
+	publicKeyRefetchCooldown = time.Minute
+	// publicKeyFetchTimeout limits how long the key is fetched, since client's HTTP client might have
+	// no timeout.
+	publicKeyFetchTimeout = 10 * time.Second
+)
+
+type (
+	// apiPublicKeySource provides public key obtained from the Kick's API with Client.PublicKey. Parsed key
+	// is cached and refreshed in the background, fallback key is used until the key is fetched for the first
+	// time. Requests wait for the key only if there is neither cached nor fallback key.
+	apiPublicKeySource struct {
+		client          *Client
+		refreshInterval time.Duration
+		fallback        *rsa.PublicKey
+		now             func() time.Time
+
+		locker      sync.Mutex
+		key         *rsa.PublicKey
+		nextFetch   time.Time
+		refetchedAt time.Time
+		// inflight is a fetch in progress, there is only one at a time.
+		inflight *publicKeyFetch
+	}
+
+	publicKeyFetch struct {
+		done chan struct{}
+		key  *rsa.PublicKey
+		err  error
+	}
+)
+
+func newAPIPublicKeySource(client *Client, refreshInterval time.Duration) *apiPublicKeySource {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultPublicKeyRefreshInterval
+	}
+
+	return &apiPublicKeySource{
+		client:          client,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// Key returns cached public key and starts its refresh in the background if the refresh interval is passed.
+// Stale or fallback key is returned while the key is being fetched or Kick's API is unreachable.
+func (s *apiPublicKeySource) Key(ctx context.Context) *rsa.PublicKey {
+	s.locker.Lock()
+
+	if !s.now().Before(s.nextFetch) {
+		s.startFetch()
+	}
+
+	var (
+		key   = s.current()
+		fetch = s.inflight
+	)
+
+	s.locker.Unlock()
+
+	if key != nil || fetch == nil {
+		return key
+	}
+
+	select {
+	case <-fetch.done:
+		return fetch.key
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// Refetch fetches public key out of schedule to handle its rotation. It returns the new key and true only
+// if the fetched key differs from the cached one.
+func (s *apiPublicKeySource) Refetch(ctx context.Context) (*rsa.PublicKey, bool) {
+	s.locker.Lock()
+
+	// Fetch in progress is joined regardless of the cooldown, since it doesn't cost another request.
+	if s.inflight == nil {
+		now := s.now()
+
+		if !s.refetchedAt.IsZero() && now.Before(s.refetchedAt.Add(publicKeyRefetchCooldown)) {
+			s.locker.Unlock()
+			return nil, false
+		}
+
+		s.refetchedAt = now
+	}
+
+	var (
+		previous = s.key
+		fetch    = s.startFetch()
+	)
+
+	s.locker.Unlock()
+
+	select {
+	case <-fetch.done:
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	if fetch.err != nil || (previous != nil && previous.Equal(fetch.key)) {
+		return nil, false
+	}
+
+	return fetch.key, true
+}
+
+func (s *apiPublicKeySource) current() *rsa.PublicKey {
+	if s.key != nil {
+		return s.key
+	}
+
+	return s.fallback
+}
+
+// startFetch starts fetching the key unless it's already being fetched, and returns the fetch in progress.
+// Source must be locked.
+func (s *apiPublicKeySource) startFetch() *publicKeyFetch {
+	if s.inflight != nil {
+		return s.inflight
+	}
+
+	fetch := &publicKeyFetch{done: make(chan struct{})}
+	s.inflight = fetch
+
+	go func() {
+		defer close(fetch.done)
+
+		// Fetch isn't bound to the request's context, since its result is shared by all the requests.
+		ctx, cancel := context.WithTimeout(context.Background(), publicKeyFetchTimeout)
+		defer cancel()
+
+		fetch.key, fetch.err = s.fetch(ctx)
+
+		s.locker.Lock()
+		defer s.locker.Unlock()
+
+		s.inflight = nil
+
+		if fetch.err != nil {
+			s.nextFetch = s.now().Add(publicKeyRefetchCooldown)
+			return
+		}
+
+		s.key = fetch.key
+		s.nextFetch = s.now().Add(s.refreshInterval)
+	}()
+
+	return fetch
+}
+
+func (s *apiPublicKeySource) fetch(ctx context.Context) (*rsa.PublicKey, error) {
+	response, err := s.client.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get public key: %w", err)
+	}
+
+	if response.ResponseMetadata.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get public key: unexpected status code %d", response.ResponseMetadata.StatusCode)
+	}
+
+	key, err := publickey.Parse([]byte(response.Payload.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	return &key, nil
+}
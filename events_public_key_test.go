@@ -0,0 +1,248 @@
+package kicksdk
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSigningKey struct {
+	privateKey *rsa.PrivateKey
+	publicPEM  string
+}
+
+func newMockSigningKey(t testing.TB) mockSigningKey {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+
+	return mockSigningKey{
+		privateKey: privateKey,
+		publicPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})),
+	}
+}
+
+func (k mockSigningKey) sign(t testing.TB, header WebhookEventHeader, body []byte) string {
+	t.Helper()
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s.%s.%s", header.MessageID, header.MessageTimestamp, body)))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, k.privateKey, crypto.SHA256, hash[:])
+	assert.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+type mockPublicKeyServer struct {
+	locker    sync.Mutex
+	publicPEM string
+	available bool
+	calls     atomic.Int32
+}
+
+func (s *mockPublicKeyServer) set(publicPEM string, available bool) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.publicPEM, s.available = publicPEM, available
+}
+
+func (s *mockPublicKeyServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.calls.Add(1)
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if !s.available {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(apiResponse[PublicKeyOutput]{
+		Payload: PublicKeyOutput{PublicKey: s.publicPEM},
+	})
+}
+
+func TestWebhookEventsHandler_PublicKeyFromAPI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body   = []byte(`{"message_id": "message-id"}`)
+		header = WebhookEventHeader{
+			MessageID:        "message-id",
+			MessageTimestamp: "2025-01-01T00:00:00Z",
+			EventType:        EventTypeChatMessage,
+		}
+	)
+
+	serve := func(handler *WebhookEventsHandler, key mockSigningKey) int {
+		var (
+			recorder = httptest.NewRecorder()
+			signed   = header
+		)
+
+		signed.Signature = key.sign(t, header, body)
+		request := newMockEventRequest(bytes.NewReader(body), signed)
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	newHandler := func(
+		t *testing.T,
+		server *mockPublicKeyServer,
+		now *time.Time,
+		options ...EventsHandlerOption,
+	) *WebhookEventsHandler {
+		t.Helper()
+
+		options = append(
+			options,
+			WithPublicKeyFromAPI(newMockClient(t, server.ServeHTTP), time.Hour),
+			WithEventsClock(func() time.Time { return *now }),
+			WithEventsHandler(func(context.Context, WebhookEventHeader, []byte) error { return nil }),
+		)
+
+		return NewWebhookEventsHandler(options...)
+	}
+
+	t.Run("Cached and refreshed key", func(t *testing.T) {
+		var (
+			key    = newMockSigningKey(t)
+			now    = time.Now()
+			server = &mockPublicKeyServer{}
+		)
+
+		server.set(key.publicPEM, true)
+		handler := newHandler(t, server, &now)
+
+		assert.Equal(t, http.StatusOK, serve(handler, key))
+		assert.Equal(t, http.StatusOK, serve(handler, key))
+		assert.Equal(t, int32(1), server.calls.Load())
+
+		now = now.Add(2 * time.Hour)
+
+		// Stale key is used while the key is refreshed in the background.
+		assert.Equal(t, http.StatusOK, serve(handler, key))
+		assert.Eventually(t, func() bool {
+			return server.calls.Load() == 2
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("Rotated key", func(t *testing.T) {
+		var (
+			oldKey = newMockSigningKey(t)
+			newKey = newMockSigningKey(t)
+			now    = time.Now()
+			server = &mockPublicKeyServer{}
+		)
+
+		server.set(oldKey.publicPEM, true)
+		handler := newHandler(t, server, &now)
+
+		assert.Equal(t, http.StatusOK, serve(handler, oldKey))
+
+		server.set(newKey.publicPEM, true)
+
+		assert.Equal(t, http.StatusOK, serve(handler, newKey))
+		assert.Equal(t, int32(2), server.calls.Load())
+
+		// Refetch is limited by the cooldown, so forged events don't hit Kick's API every time.
+		assert.Equal(t, http.StatusForbidden, serve(handler, newMockSigningKey(t)))
+		assert.Equal(t, http.StatusForbidden, serve(handler, newMockSigningKey(t)))
+		assert.Equal(t, int32(2), server.calls.Load())
+	})
+
+	t.Run("Fallback key", func(t *testing.T) {
+		var (
+			key    = newMockSigningKey(t)
+			now    = time.Now()
+			server = &mockPublicKeyServer{}
+		)
+
+		server.set("", false)
+		handler := newHandler(t, server, &now, WithPublicKey(key.publicPEM))
+
+		assert.Equal(t, http.StatusOK, serve(handler, key))
+		assert.Equal(t, http.StatusForbidden, serve(handler, newMockSigningKey(t)))
+	})
+
+	t.Run("Hanging key endpoint", func(t *testing.T) {
+		var (
+			key     = newMockSigningKey(t)
+			now     = time.Now()
+			release = make(chan struct{})
+			calls   atomic.Int32
+		)
+
+		client := newMockClient(t, func(http.ResponseWriter, *http.Request) {
+			calls.Add(1)
+			<-release
+		})
+
+		// Registered after the mock server, so the hanging request is released before the server is closed.
+		t.Cleanup(func() {
+			close(release)
+		})
+
+		handler := NewWebhookEventsHandler(
+			WithPublicKey(key.publicPEM),
+			WithPublicKeyFromAPI(client, time.Hour),
+			WithEventsClock(func() time.Time { return now }),
+			WithEventsHandler(func(context.Context, WebhookEventHeader, []byte) error { return nil }),
+		)
+
+		done := make(chan int, 2)
+
+		go func() {
+			done <- serve(handler, key)
+			done <- serve(handler, key)
+		}()
+
+		for range 2 {
+			select {
+			case code := <-done:
+				assert.Equal(t, http.StatusOK, code)
+			case <-time.After(time.Second):
+				t.Fatal("request is blocked by the key fetch")
+			}
+		}
+
+		// Unverified event waits for the rotated key only until its request is canceled.
+		var (
+			forged   = header
+			recorder = httptest.NewRecorder()
+		)
+
+		forged.Signature = newMockSigningKey(t).sign(t, header, body)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		handler.ServeHTTP(recorder, newMockEventRequest(bytes.NewReader(body), forged).WithContext(ctx))
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
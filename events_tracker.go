@@ -11,6 +11,12 @@ type EventsTracker interface {
 	Track(ctx context.Context, eventID string) (bool, error)
 }
 
+// EventsForgetter is an optional interface of the EventsTracker that allows to stop tracking an event, so
+// its redelivery is not considered duplicate. It's used when the event's callback fails in the synchronous mode.
+type EventsForgetter interface {
+	Forget(ctx context.Context, eventID string) error
+}
+
 // MapEventsTracker is a primitive concurrency-safe in-memory implementation of the EventsTracker.
 type MapEventsTracker struct {
 	events       map[string]struct{}
@@ -36,3 +42,12 @@ func (met *MapEventsTracker) Track(_ context.Context, eventID string) (bool, err
 
 	return false, nil
 }
+
+func (met *MapEventsTracker) Forget(_ context.Context, eventID string) error {
+	met.eventsLocker.Lock()
+	defer met.eventsLocker.Unlock()
+
+	delete(met.events, eventID)
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package kicksdk
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTrackerTTL        = time.Hour
+	defaultTrackerMaxEntries = 100_000
+	defaultTrackerShards     = 16
+)
+
+type (
+	// EventsTrackerStats is a snapshot of the tracker's statistics.
+	EventsTrackerStats struct {
+		// Size is a number of currently tracked events.
+		Size int
+		// Evictions is a number of events evicted to not exceed the maximum number of entries.
+		Evictions uint64
+		// Hits is a number of tracked events that turned out to be duplicates.
+		Hits uint64
+		// Misses is a number of tracked events that were not seen before.
+		Misses uint64
+	}
+
+	// ExpiringEventsTracker is a concurrency-safe in-memory implementation of the EventsTracker with bounded
+	// memory usage. Events are forgotten once they are not seen for the TTL, and the least recently seen events
+	// are evicted when the maximum number of entries is exceeded.
+	ExpiringEventsTracker struct {
+		shards      []*trackerShard
+		shardsCount int
+		ttl         time.Duration
+		maxEntries  int
+		now         func() time.Time
+	}
+
+	// trackerShard is a part of the tracker's entries with its own lock and LRU list, front of the list is
+	// the most recently seen event. Every event expires in the same TTL since it's seen, so the list is
+	// ordered by expiration time as well.
+	trackerShard struct {
+		locker   sync.Mutex
+		entries  map[string]*list.Element
+		order    *list.List
+		capacity int
+
+		// Statistics are counted per shard, so tracking doesn't contend on the shared counters.
+		evictions uint64
+		hits      uint64
+		misses    uint64
+	}
+
+	trackerEntry struct {
+		eventID   string
+		expiresAt time.Time
+	}
+)
+
+// HitRate returns ratio of the duplicate events to all the tracked events.
+func (s EventsTrackerStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+func NewExpiringEventsTracker(options ...ExpiringEventsTrackerOption) *ExpiringEventsTracker {
+	tracker := &ExpiringEventsTracker{
+		ttl:         defaultTrackerTTL,
+		maxEntries:  defaultTrackerMaxEntries,
+		now:         time.Now,
+		shardsCount: defaultTrackerShards,
+	}
+
+	for _, option := range options {
+		option(tracker)
+	}
+
+	tracker.shards = make([]*trackerShard, max(tracker.shardsCount, 1))
+
+	// Maximum number of entries is split between shards, so the total doesn't exceed it unless there are
+	// fewer entries than shards. Remainder is distributed among the first shards.
+	var (
+		capacity  = tracker.maxEntries / len(tracker.shards)
+		remainder = tracker.maxEntries % len(tracker.shards)
+	)
+
+	for i := range tracker.shards {
+		shardCapacity := capacity
+		if i < remainder {
+			shardCapacity++
+		}
+
+		tracker.shards[i] = &trackerShard{
+			entries:  make(map[string]*list.Element),
+			order:    list.New(),
+			capacity: max(shardCapacity, 1),
+		}
+	}
+
+	return tracker
+}
+
+func (eet *ExpiringEventsTracker) Track(_ context.Context, eventID string) (bool, error) {
+	var (
+		shard = eet.shards[shardIndex(eventID, len(eet.shards))]
+		now   = eet.now()
+	)
+
+	shard.locker.Lock()
+	defer shard.locker.Unlock()
+
+	if element, exist := shard.entries[eventID]; exist {
+		if entry := element.Value.(*trackerEntry); now.Before(entry.expiresAt) {
+			// Expiration is refreshed along with the position, so the list stays ordered by expiration time.
+			entry.expiresAt = now.Add(eet.ttl)
+			shard.order.MoveToFront(element)
+			shard.hits++
+
+			return true, nil
+		}
+
+		shard.remove(element)
+	}
+
+	shard.misses++
+
+	shard.entries[eventID] = shard.order.PushFront(&trackerEntry{
+		eventID:   eventID,
+		expiresAt: now.Add(eet.ttl),
+	})
+
+	shard.removeExpired(now)
+
+	for len(shard.entries) > shard.capacity {
+		shard.remove(shard.order.Back())
+		shard.evictions++
+	}
+
+	return false, nil
+}
+
+func (eet *ExpiringEventsTracker) Forget(_ context.Context, eventID string) error {
+	shard := eet.shards[shardIndex(eventID, len(eet.shards))]
+
+	shard.locker.Lock()
+	defer shard.locker.Unlock()
+
+	if element, exist := shard.entries[eventID]; exist {
+		shard.remove(element)
+	}
+
+	return nil
+}
+
+// Stats returns current statistics of the tracker.
+func (eet *ExpiringEventsTracker) Stats() EventsTrackerStats {
+	var stats EventsTrackerStats
+
+	for _, shard := range eet.shards {
+		shard.locker.Lock()
+
+		stats.Size += len(shard.entries)
+		stats.Evictions += shard.evictions
+		stats.Hits += shard.hits
+		stats.Misses += shard.misses
+
+		shard.locker.Unlock()
+	}
+
+	return stats
+}
+
+func (ts *trackerShard) remove(element *list.Element) {
+	ts.order.Remove(element)
+	delete(ts.entries, element.Value.(*trackerEntry).eventID)
+}
+
+// removeExpired removes expired entries from the back of the list, where the least recently seen entries
+// that are the first to expire are.
+func (ts *trackerShard) removeExpired(now time.Time) {
+	for element := ts.order.Back(); element != nil; element = ts.order.Back() {
+		if now.Before(element.Value.(*trackerEntry).expiresAt) {
+			return
+		}
+
+		ts.remove(element)
+	}
+}
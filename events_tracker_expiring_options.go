@@ -0,0 +1,35 @@
+package kicksdk
+
+import "time"
+
+type ExpiringEventsTrackerOption func(*ExpiringEventsTracker)
+
+// WithTrackerTTL sets for how long events are tracked since they are seen for the last time, 1 hour by default.
+func WithTrackerTTL(ttl time.Duration) ExpiringEventsTrackerOption {
+	return func(tracker *ExpiringEventsTracker) {
+		tracker.ttl = ttl
+	}
+}
+
+// WithTrackerMaxEntries sets maximum number of tracked events, 100000 by default. It's split evenly between
+// shards and every shard evicts its least recently seen events once its own part is exceeded, so eviction
+// might start before the total is reached if events are distributed unevenly.
+func WithTrackerMaxEntries(maxEntries int) ExpiringEventsTrackerOption {
+	return func(tracker *ExpiringEventsTracker) {
+		tracker.maxEntries = maxEntries
+	}
+}
+
+// WithTrackerShards sets number of the independently locked shards that events are split into, 16 by default.
+func WithTrackerShards(shards int) ExpiringEventsTrackerOption {
+	return func(tracker *ExpiringEventsTracker) {
+		tracker.shardsCount = shards
+	}
+}
+
+// WithTrackerClock sets function that returns current time.
+func WithTrackerClock(now func() time.Time) ExpiringEventsTrackerOption {
+	return func(tracker *ExpiringEventsTracker) {
+		tracker.now = now
+	}
+}
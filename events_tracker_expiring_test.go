@@ -0,0 +1,177 @@
+package kicksdk
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiringEventsTracker_Track(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Duplicate event", func(t *testing.T) {
+		tracker := NewExpiringEventsTracker()
+
+		duplicate, err := tracker.Track(context.Background(), "event-id")
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+
+		duplicate, err = tracker.Track(context.Background(), "event-id")
+		assert.NoError(t, err)
+		assert.True(t, duplicate)
+
+		assert.Equal(t, EventsTrackerStats{Size: 1, Hits: 1, Misses: 1}, tracker.Stats())
+		assert.InDelta(t, 0.5, tracker.Stats().HitRate(), 0.001)
+	})
+
+	t.Run("Expired event", func(t *testing.T) {
+		now := time.Now()
+
+		tracker := NewExpiringEventsTracker(
+			WithTrackerShards(1),
+			WithTrackerTTL(time.Minute),
+			WithTrackerClock(func() time.Time { return now }),
+		)
+
+		_, _ = tracker.Track(context.Background(), "first-event-id")
+
+		now = now.Add(2 * time.Minute)
+
+		duplicate, err := tracker.Track(context.Background(), "first-event-id")
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+
+		_, _ = tracker.Track(context.Background(), "second-event-id")
+
+		now = now.Add(2 * time.Minute)
+
+		// Expired entries are removed on insert, even if they are not tracked again.
+		_, _ = tracker.Track(context.Background(), "third-event-id")
+
+		assert.Equal(t, 1, tracker.Stats().Size)
+		assert.Equal(t, uint64(0), tracker.Stats().Evictions)
+	})
+
+	t.Run("Expiration is refreshed on duplicate", func(t *testing.T) {
+		now := time.Now()
+
+		tracker := NewExpiringEventsTracker(
+			WithTrackerShards(1),
+			WithTrackerTTL(time.Minute),
+			WithTrackerClock(func() time.Time { return now }),
+		)
+
+		_, _ = tracker.Track(context.Background(), "first-event-id")
+
+		now = now.Add(50 * time.Second)
+
+		_, _ = tracker.Track(context.Background(), "second-event-id")
+		_, _ = tracker.Track(context.Background(), "first-event-id")
+
+		now = now.Add(50 * time.Second)
+
+		duplicate, _ := tracker.Track(context.Background(), "first-event-id")
+		assert.True(t, duplicate)
+
+		now = now.Add(2 * time.Minute)
+
+		// Entries that were seen recently expire too.
+		_, _ = tracker.Track(context.Background(), "third-event-id")
+		assert.Equal(t, 1, tracker.Stats().Size)
+	})
+
+	t.Run("Capacity of shards", func(t *testing.T) {
+		tracker := NewExpiringEventsTracker(WithTrackerShards(3), WithTrackerMaxEntries(10))
+
+		var capacity int
+
+		for _, shard := range tracker.shards {
+			capacity += shard.capacity
+		}
+
+		assert.Len(t, tracker.shards, 3)
+		assert.Equal(t, 10, capacity)
+	})
+
+	t.Run("Least recently seen event is evicted", func(t *testing.T) {
+		tracker := NewExpiringEventsTracker(WithTrackerShards(1), WithTrackerMaxEntries(2))
+
+		for _, eventID := range []string{"first", "second", "first", "third"} {
+			_, _ = tracker.Track(context.Background(), eventID)
+		}
+
+		assert.Equal(t, EventsTrackerStats{Size: 2, Evictions: 1, Hits: 1, Misses: 3}, tracker.Stats())
+
+		duplicate, _ := tracker.Track(context.Background(), "first")
+		assert.True(t, duplicate)
+
+		duplicate, _ = tracker.Track(context.Background(), "second")
+		assert.False(t, duplicate)
+	})
+
+	t.Run("Forgotten event", func(t *testing.T) {
+		tracker := NewExpiringEventsTracker()
+
+		_, _ = tracker.Track(context.Background(), "event-id")
+		assert.NoError(t, tracker.Forget(context.Background(), "event-id"))
+
+		duplicate, _ := tracker.Track(context.Background(), "event-id")
+		assert.False(t, duplicate)
+	})
+
+	t.Run("Bounded size", func(t *testing.T) {
+		tracker := NewExpiringEventsTracker(WithTrackerMaxEntries(1000))
+
+		for i := range 10_000 {
+			_, _ = tracker.Track(context.Background(), strconv.Itoa(i))
+		}
+
+		stats := tracker.Stats()
+		assert.LessOrEqual(t, stats.Size, 1000)
+		assert.Equal(t, uint64(10_000-stats.Size), stats.Evictions)
+	})
+}
+
+func TestEventsTrackerStats_HitRate(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, EventsTrackerStats{}.HitRate())
+	assert.InDelta(t, 0.25, EventsTrackerStats{Hits: 1, Misses: 3}.HitRate(), 0.001)
+}
+
+func benchmarkEventsTracker(b *testing.B, tracker EventsTracker) {
+	b.Helper()
+
+	var workers atomic.Int64
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		var (
+			prefix = strconv.FormatInt(workers.Add(1), 10) + "-"
+			id     = 0
+		)
+
+		for pb.Next() {
+			id++
+
+			// Every fourth event is a redelivery of the previous one.
+			if _, err := tracker.Track(context.Background(), prefix+strconv.Itoa(id-id%4)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMapEventsTracker_Track(b *testing.B) {
+	benchmarkEventsTracker(b, NewMapEventsTracker())
+}
+
+func BenchmarkExpiringEventsTracker_Track(b *testing.B) {
+	benchmarkEventsTracker(b, NewExpiringEventsTracker())
+}
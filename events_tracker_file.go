@@ -0,0 +1,407 @@
+package kicksdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFileTrackerRetention = 24 * time.Hour
+	// minCompactionRecords is a number of records in the log below which it's never compacted automatically.
+	minCompactionRecords = 1024
+	// maxFileRecordSize is a maximum size of the single record in the log.
+	maxFileRecordSize = 64 << 10
+)
+
+var ErrTrackerClosed = errors.New("events tracker is closed")
+
+type (
+	// FileEventsTracker is a concurrency-safe implementation of the EventsTracker that persists tracked events
+	// to the append-only log on the local disk, so deduplication state survives restarts. Expired events are
+	// removed from memory as new events are tracked, and the log is compacted once most of its records belong
+	// to expired or forgotten events, as well as periodically if the interval is set.
+	FileEventsTracker struct {
+		path      string
+		retention time.Duration
+		sync      bool
+		now       func() time.Time
+
+		compactionInterval time.Duration
+
+		locker sync.Mutex
+		file   *os.File
+		events map[string]time.Time
+		// expirations are tracked events in order of their tracking, so expired ones are removed from
+		// the front. Entries of the forgotten or retracked events are skipped.
+		expirations []fileTrackerEntry
+		records     int
+		closed      bool
+
+		stop chan struct{}
+		wg   sync.WaitGroup
+	}
+
+	fileTrackerEntry struct {
+		eventID   string
+		trackedAt time.Time
+	}
+
+	// fileTrackerRecord is a single line of the log.
+	fileTrackerRecord struct {
+		EventID   string `json:"id"`
+		TrackedAt int64  `json:"at,omitempty"`
+		Forgotten bool   `json:"forgotten,omitempty"`
+	}
+)
+
+// NewFileEventsTracker opens the log at the provided path, or creates it if it doesn't exist, and restores
+// events tracked within the retention window.
+func NewFileEventsTracker(path string, options ...FileEventsTrackerOption) (*FileEventsTracker, error) {
+	tracker := &FileEventsTracker{
+		path:      path,
+		retention: defaultFileTrackerRetention,
+		sync:      true,
+		now:       time.Now,
+		events:    make(map[string]time.Time),
+		stop:      make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(tracker)
+	}
+
+	if err := tracker.load(); err != nil {
+		return nil, err
+	}
+
+	// Log is compacted on start, so it doesn't carry events expired while the tracker was not running.
+	if err := tracker.compact(); err != nil {
+		return nil, err
+	}
+
+	if tracker.compactionInterval > 0 {
+		tracker.wg.Add(1)
+		go tracker.compactPeriodically()
+	}
+
+	return tracker, nil
+}
+
+func (fet *FileEventsTracker) Track(_ context.Context, eventID string) (bool, error) {
+	fet.locker.Lock()
+	defer fet.locker.Unlock()
+
+	if fet.closed {
+		return false, ErrTrackerClosed
+	}
+
+	now := fet.now()
+
+	if trackedAt, exist := fet.events[eventID]; exist && !fet.expired(trackedAt, now) {
+		return true, nil
+	}
+
+	if err := fet.append(fileTrackerRecord{EventID: eventID, TrackedAt: now.UnixNano()}); err != nil {
+		return false, err
+	}
+
+	fet.events[eventID] = now
+
+	if fet.retention > 0 {
+		fet.expirations = append(fet.expirations, fileTrackerEntry{eventID: eventID, trackedAt: now})
+	}
+
+	fet.removeExpired(now)
+
+	// Event is already persisted, and the log stays valid if compaction fails, so it's retried later.
+	_ = fet.maybeCompact()
+
+	return false, nil
+}
+
+func (fet *FileEventsTracker) Forget(_ context.Context, eventID string) error {
+	fet.locker.Lock()
+	defer fet.locker.Unlock()
+
+	if fet.closed {
+		return ErrTrackerClosed
+	}
+
+	if _, exist := fet.events[eventID]; !exist {
+		return nil
+	}
+
+	if err := fet.append(fileTrackerRecord{EventID: eventID, Forgotten: true}); err != nil {
+		return err
+	}
+
+	delete(fet.events, eventID)
+
+	return nil
+}
+
+// Compact rewrites the log with only the events tracked within the retention window.
+func (fet *FileEventsTracker) Compact() error {
+	fet.locker.Lock()
+	defer fet.locker.Unlock()
+
+	if fet.closed {
+		return ErrTrackerClosed
+	}
+
+	return fet.compactLocked()
+}
+
+// Close stops periodic compaction and closes the log.
+func (fet *FileEventsTracker) Close() error {
+	fet.locker.Lock()
+
+	if fet.closed {
+		fet.locker.Unlock()
+		return nil
+	}
+
+	fet.closed = true
+	close(fet.stop)
+
+	fet.locker.Unlock()
+
+	fet.wg.Wait()
+
+	fet.locker.Lock()
+	defer fet.locker.Unlock()
+
+	if fet.file == nil {
+		return nil
+	}
+
+	return fet.file.Close()
+}
+
+func (fet *FileEventsTracker) expired(trackedAt, now time.Time) bool {
+	return fet.retention > 0 && !now.Before(trackedAt.Add(fet.retention))
+}
+
+// load restores events from the log. Malformed records are skipped, since the last record might be
+// partially written if the process crashed.
+func (fet *FileEventsTracker) load() error {
+	file, err := os.Open(fet.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("open events log: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), maxFileRecordSize)
+
+	now := fet.now()
+
+	for scanner.Scan() {
+		var record fileTrackerRecord
+
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil || len(record.EventID) == 0 {
+			continue
+		}
+
+		fet.records++
+
+		if record.Forgotten {
+			delete(fet.events, record.EventID)
+			continue
+		}
+
+		if trackedAt := time.Unix(0, record.TrackedAt); !fet.expired(trackedAt, now) {
+			fet.events[record.EventID] = trackedAt
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("read events log: %w", err)
+	}
+
+	return nil
+}
+
+func (fet *FileEventsTracker) compact() error {
+	fet.locker.Lock()
+	defer fet.locker.Unlock()
+
+	return fet.compactLocked()
+}
+
+// removeExpired removes expired events from the front of the expirations. Tracker must be locked.
+func (fet *FileEventsTracker) removeExpired(now time.Time) {
+	count := 0
+
+	for ; count < len(fet.expirations); count++ {
+		entry := fet.expirations[count]
+
+		if !fet.expired(entry.trackedAt, now) {
+			break
+		}
+
+		// Event might be forgotten or tracked again since the entry was added.
+		if trackedAt, exist := fet.events[entry.eventID]; exist && trackedAt.Equal(entry.trackedAt) {
+			delete(fet.events, entry.eventID)
+		}
+	}
+
+	fet.expirations = fet.expirations[count:]
+}
+
+// maybeCompact compacts the log if most of its records are no longer needed.
+func (fet *FileEventsTracker) maybeCompact() error {
+	if fet.records < minCompactionRecords || fet.records < 2*len(fet.events) {
+		return nil
+	}
+
+	return fet.compactLocked()
+}
+
+// compactLocked writes live events to the temporary file and atomically replaces the log with it, so the log
+// is never left partially written. Tracker must be locked.
+func (fet *FileEventsTracker) compactLocked() error {
+	var (
+		now       = fet.now()
+		directory = filepath.Dir(fet.path)
+	)
+
+	temporary, err := os.CreateTemp(directory, filepath.Base(fet.path)+".compact-*")
+	if err != nil {
+		return fmt.Errorf("create compacted events log: %w", err)
+	}
+
+	defer func() {
+		// Temporary file exists only if compaction failed, otherwise it's already renamed.
+		_ = temporary.Close()
+		_ = os.Remove(temporary.Name())
+	}()
+
+	var (
+		writer  = bufio.NewWriter(temporary)
+		encoder = json.NewEncoder(writer)
+	)
+
+	for eventID, trackedAt := range fet.events {
+		if fet.expired(trackedAt, now) {
+			delete(fet.events, eventID)
+			continue
+		}
+
+		record := fileTrackerRecord{EventID: eventID, TrackedAt: trackedAt.UnixNano()}
+
+		if err = encoder.Encode(record); err != nil {
+			return fmt.Errorf("write compacted events log: %w", err)
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("write compacted events log: %w", err)
+	}
+
+	if err = temporary.Sync(); err != nil {
+		return fmt.Errorf("sync compacted events log: %w", err)
+	}
+
+	if err = os.Rename(temporary.Name(), fet.path); err != nil {
+		return fmt.Errorf("replace events log: %w", err)
+	}
+
+	syncDirectory(directory)
+
+	if fet.file != nil {
+		_ = fet.file.Close()
+	}
+
+	fet.file, err = os.OpenFile(fet.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		fet.file = nil
+		return fmt.Errorf("open events log: %w", err)
+	}
+
+	fet.records = len(fet.events)
+
+	fet.expirations = fet.expirations[:0:0]
+
+	if fet.retention > 0 {
+		for eventID, trackedAt := range fet.events {
+			fet.expirations = append(fet.expirations, fileTrackerEntry{eventID: eventID, trackedAt: trackedAt})
+		}
+
+		slices.SortFunc(fet.expirations, func(a, b fileTrackerEntry) int {
+			return a.trackedAt.Compare(b.trackedAt)
+		})
+	}
+
+	return nil
+}
+
+// append writes the record to the log with a single write, so concurrent readers never see it partially.
+func (fet *FileEventsTracker) append(record fileTrackerRecord) error {
+	if fet.file == nil {
+		return fmt.Errorf("write events log: %w", os.ErrClosed)
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	if _, err = fet.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write events log: %w", err)
+	}
+
+	if fet.sync {
+		if err = fet.file.Sync(); err != nil {
+			return fmt.Errorf("sync events log: %w", err)
+		}
+	}
+
+	fet.records++
+
+	return nil
+}
+
+func (fet *FileEventsTracker) compactPeriodically() {
+	defer fet.wg.Done()
+
+	ticker := time.NewTicker(fet.compactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fet.stop:
+			return
+		case <-ticker.C:
+			// Failed compaction is retried on the next tick, the log is still valid.
+			_ = fet.Compact()
+		}
+	}
+}
+
+// syncDirectory makes rename of the file in the directory durable. It's not supported on every platform,
+// so errors are ignored.
+func syncDirectory(path string) {
+	directory, err := os.Open(path)
+	if err != nil {
+		return
+	}
+
+	_ = directory.Sync()
+	_ = directory.Close()
+}
@@ -0,0 +1,37 @@
+package kicksdk
+
+import "time"
+
+type FileEventsTrackerOption func(*FileEventsTracker)
+
+// WithFileTrackerRetention sets for how long events are tracked, 24 hours by default. Events are tracked
+// forever if it's zero.
+func WithFileTrackerRetention(retention time.Duration) FileEventsTrackerOption {
+	return func(tracker *FileEventsTracker) {
+		tracker.retention = retention
+	}
+}
+
+// WithFileTrackerCompactionInterval enables compaction of the log with the provided interval, in addition to
+// the compaction that happens once the log is mostly made of expired events.
+func WithFileTrackerCompactionInterval(interval time.Duration) FileEventsTrackerOption {
+	return func(tracker *FileEventsTracker) {
+		tracker.compactionInterval = interval
+	}
+}
+
+// WithFileTrackerSync sets whether every record is flushed to the disk before the event is reported as tracked,
+// which is enabled by default. Disabling it speeds up tracking, but events tracked right before the crash of
+// the machine might be lost.
+func WithFileTrackerSync(sync bool) FileEventsTrackerOption {
+	return func(tracker *FileEventsTracker) {
+		tracker.sync = sync
+	}
+}
+
+// WithFileTrackerClock sets function that returns current time.
+func WithFileTrackerClock(now func() time.Time) FileEventsTrackerOption {
+	return func(tracker *FileEventsTracker) {
+		tracker.now = now
+	}
+}
@@ -0,0 +1,252 @@
+package kicksdk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFileEventsTracker(t *testing.T, path string, options ...FileEventsTrackerOption) *FileEventsTracker {
+	t.Helper()
+
+	tracker, err := NewFileEventsTracker(path, options...)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = tracker.Close()
+	})
+
+	return tracker
+}
+
+func countLogRecords(t *testing.T, path string) int {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	return strings.Count(string(content), "\n")
+}
+
+func TestFileEventsTracker_Track(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Events survive restart", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.log")
+
+		tracker := newTestFileEventsTracker(t, path)
+
+		duplicate, err := tracker.Track(context.Background(), "first-event-id")
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+
+		duplicate, err = tracker.Track(context.Background(), "first-event-id")
+		assert.NoError(t, err)
+		assert.True(t, duplicate)
+
+		_, _ = tracker.Track(context.Background(), "second-event-id")
+		assert.NoError(t, tracker.Forget(context.Background(), "second-event-id"))
+		assert.NoError(t, tracker.Close())
+
+		restarted := newTestFileEventsTracker(t, path)
+
+		duplicate, err = restarted.Track(context.Background(), "first-event-id")
+		assert.NoError(t, err)
+		assert.True(t, duplicate)
+
+		duplicate, err = restarted.Track(context.Background(), "second-event-id")
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+	})
+
+	t.Run("Retention window", func(t *testing.T) {
+		var (
+			path = filepath.Join(t.TempDir(), "events.log")
+			now  = time.Now()
+			mu   sync.Mutex
+		)
+
+		clock := WithFileTrackerClock(func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return now
+		})
+
+		tracker := newTestFileEventsTracker(t, path, WithFileTrackerRetention(time.Hour), clock)
+
+		_, _ = tracker.Track(context.Background(), "old-event-id")
+
+		mu.Lock()
+		now = now.Add(30 * time.Minute)
+		mu.Unlock()
+
+		_, _ = tracker.Track(context.Background(), "new-event-id")
+		assert.NoError(t, tracker.Close())
+
+		mu.Lock()
+		now = now.Add(45 * time.Minute)
+		mu.Unlock()
+
+		restarted := newTestFileEventsTracker(t, path, WithFileTrackerRetention(time.Hour), clock)
+
+		// Expired events are dropped from the log on start.
+		assert.Equal(t, 1, countLogRecords(t, path))
+
+		duplicate, _ := restarted.Track(context.Background(), "new-event-id")
+		assert.True(t, duplicate)
+
+		duplicate, _ = restarted.Track(context.Background(), "old-event-id")
+		assert.False(t, duplicate)
+	})
+
+	t.Run("Partially written record", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.log")
+
+		content := `{"id":"event-id","at":` + strconv.FormatInt(time.Now().UnixNano(), 10) + "}\n" + `{"id":"brok`
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		tracker := newTestFileEventsTracker(t, path)
+
+		duplicate, err := tracker.Track(context.Background(), "event-id")
+		assert.NoError(t, err)
+		assert.True(t, duplicate)
+
+		duplicate, err = tracker.Track(context.Background(), "brok")
+		assert.NoError(t, err)
+		assert.False(t, duplicate)
+	})
+
+	t.Run("Closed tracker", func(t *testing.T) {
+		tracker := newTestFileEventsTracker(t, filepath.Join(t.TempDir(), "events.log"))
+		assert.NoError(t, tracker.Close())
+		assert.NoError(t, tracker.Close())
+
+		_, err := tracker.Track(context.Background(), "event-id")
+		assert.ErrorIs(t, err, ErrTrackerClosed)
+		assert.ErrorIs(t, tracker.Forget(context.Background(), "event-id"), ErrTrackerClosed)
+	})
+
+	t.Run("Concurrent tracking", func(t *testing.T) {
+		var (
+			tracker    = newTestFileEventsTracker(t, filepath.Join(t.TempDir(), "events.log"), WithFileTrackerSync(false))
+			wg         sync.WaitGroup
+			duplicates sync.Map
+		)
+
+		for worker := range 8 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for i := range 100 {
+					duplicate, err := tracker.Track(context.Background(), strconv.Itoa(i))
+					assert.NoError(t, err)
+
+					if !duplicate {
+						_, loaded := duplicates.LoadOrStore(i, worker)
+						assert.False(t, loaded, "event is tracked twice")
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestFileEventsTracker_Compact(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.log")
+
+	tracker := newTestFileEventsTracker(t, path, WithFileTrackerSync(false))
+
+	for i := range 10 {
+		_, _ = tracker.Track(context.Background(), strconv.Itoa(i))
+
+		if i%2 == 0 {
+			assert.NoError(t, tracker.Forget(context.Background(), strconv.Itoa(i)))
+		}
+	}
+
+	assert.Equal(t, 15, countLogRecords(t, path))
+	assert.NoError(t, tracker.Compact())
+	assert.Equal(t, 5, countLogRecords(t, path))
+
+	// Log is still appended after compaction.
+	_, _ = tracker.Track(context.Background(), "10")
+	assert.Equal(t, 6, countLogRecords(t, path))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	t.Run("Automatic compaction", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.log")
+
+		tracker := newTestFileEventsTracker(t, path, WithFileTrackerSync(false))
+
+		for range minCompactionRecords {
+			_, _ = tracker.Track(context.Background(), "event-id")
+			assert.NoError(t, tracker.Forget(context.Background(), "event-id"))
+		}
+
+		assert.Less(t, countLogRecords(t, path), 2*minCompactionRecords)
+	})
+
+	t.Run("Compaction of expired events", func(t *testing.T) {
+		var (
+			path = filepath.Join(t.TempDir(), "events.log")
+			now  = time.Now()
+		)
+
+		tracker := newTestFileEventsTracker(
+			t,
+			path,
+			WithFileTrackerSync(false),
+			WithFileTrackerRetention(time.Hour),
+			WithFileTrackerClock(func() time.Time { return now }),
+		)
+
+		for i := range minCompactionRecords {
+			_, _ = tracker.Track(context.Background(), strconv.Itoa(i))
+		}
+
+		assert.Len(t, tracker.events, minCompactionRecords)
+		assert.Equal(t, minCompactionRecords, countLogRecords(t, path))
+
+		now = now.Add(2 * time.Hour)
+
+		_, _ = tracker.Track(context.Background(), "new-event-id")
+
+		assert.Len(t, tracker.events, 1)
+		assert.Equal(t, 1, countLogRecords(t, path))
+	})
+
+	t.Run("Periodic compaction", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.log")
+
+		tracker := newTestFileEventsTracker(
+			t,
+			path,
+			WithFileTrackerSync(false),
+			WithFileTrackerCompactionInterval(10*time.Millisecond),
+		)
+
+		_, _ = tracker.Track(context.Background(), "event-id")
+		assert.NoError(t, tracker.Forget(context.Background(), "event-id"))
+
+		assert.Eventually(t, func() bool {
+			return countLogRecords(t, path) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+}
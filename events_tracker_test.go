@@ -36,4 +36,19 @@ func TestNewMapEventsTracker(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, exists)
 	})
+
+	t.Run("Forget tracked event", func(t *testing.T) {
+		var (
+			tracker = NewMapEventsTracker()
+			eventID = "test"
+		)
+
+		tracker.events[eventID] = struct{}{}
+
+		assert.NoError(t, tracker.Forget(context.Background(), eventID))
+
+		exists, err := tracker.Track(context.Background(), eventID)
+		assert.NoError(t, err)
+		assert.Equal(t, false, exists)
+	})
 }
@@ -23,20 +23,15 @@ func ExtractWebhookEventHeader(request *http.Request) WebhookEventHeader {
 }
 
 // VerifyWebhookEvent verifies webhook event signature to ensure that event with provided header and Body
-// was actually sent from the Kick's server.
+// was actually sent from the Kick's server. Public key is parsed on every call, use WebhookVerifier to
+// verify multiple events with the same key.
 //
 // Reference: https://docs.kick.com/events/webhook-security#webhook-sender-validation
 func VerifyWebhookEvent(header WebhookEventHeader, publicKey string, body []byte) error {
-	signature := []byte(fmt.Sprintf("%s.%s.%s", header.MessageID, header.MessageTimestamp, body))
-
 	rsaPublicKey, err := publickey.Parse([]byte(publicKey))
 	if err != nil {
 		return fmt.Errorf("parse public key: %w", err)
 	}
 
-	if err = publickey.VerifyEventSignature(&rsaPublicKey, signature, []byte(header.Signature)); err != nil {
-		return fmt.Errorf("verify event signature: %w", err)
-	}
-
-	return nil
+	return verifyWebhookEvent(header, &rsaPublicKey, body)
 }
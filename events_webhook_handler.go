@@ -1,17 +1,44 @@
 package kicksdk
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"mime"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/glichtv/kick-sdk/internal/publickey"
 )
 
-var ErrUnexpectedEventType = errors.New("unexpected event type")
+const (
+	defaultMaxBodyBytes = 1 << 20
+	// maxPooledBodyBufferSize is a capacity of the body buffer after which it's not returned to the pool,
+	// so a single large event doesn't keep its memory forever.
+	maxPooledBodyBufferSize = 64 << 10
+)
+
+var bodyBuffersPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+var (
+	ErrMethodNotAllowed       = errors.New("method is not allowed")
+	ErrUnsupportedContentType = errors.New("content type is not supported")
+	ErrMissingEventHeader     = errors.New("required event header is missing")
+	ErrBodyTooLarge           = errors.New("event body is too large")
+	ErrUnreadableBody         = errors.New("event body can't be read")
+	ErrInvalidSignature       = errors.New("event signature is invalid")
+
+	ErrUnexpectedEventType     = errors.New("unexpected event type")
+	ErrUnsupportedEventVersion = errors.New("unsupported event version")
+	ErrInvalidEventTimestamp   = errors.New("event timestamp is invalid")
+	ErrStaleEvent              = errors.New("event timestamp is outside of the allowed window")
+)
 
 type (
 	WebhookEventHeader struct {
@@ -24,7 +51,20 @@ type (
 	}
 
 	WebhookEventCallback[Payload any] func(WebhookEventHeader, Payload)
-	WebhookEventHandlerFunc           func(context.Context, WebhookEventHeader, []byte) error
+
+	// WebhookEventContextCallback is a callback that receives request's context and can report failure of the
+	// event processing.
+	WebhookEventContextCallback[Payload any] func(context.Context, WebhookEventHeader, Payload) error
+
+	// WebhookEventHandlerFunc handles raw event. Body is owned by the function and can be retained.
+	WebhookEventHandlerFunc func(context.Context, WebhookEventHeader, []byte) error
+
+	// UnknownEventCallback receives raw events of the types that have no registered payloads.
+	UnknownEventCallback func(context.Context, WebhookEventHeader, []byte) error
+
+	// EventVersionFallback handles raw event which type is registered, but version is not. Body is owned by
+	// the function and can be retained.
+	EventVersionFallback func(context.Context, WebhookEventHeader, []byte) error
 
 	WebhookEventsHandler struct {
 		tracker       EventsTracker
@@ -32,13 +72,41 @@ type (
 
 		verify    bool
 		publicKey string
-
-		onChatMessage                WebhookEventCallback[EventChatMessage]
-		onChannelFollow              WebhookEventCallback[EventChannelFollow]
-		onChannelSubscriptionRenewal WebhookEventCallback[EventChannelSubscriptionRenewal]
-		onChannelSubscriptionGifts   WebhookEventCallback[EventChannelSubscriptionGifts]
-		onChannelSubscriptionCreated WebhookEventCallback[EventChannelSubscriptionCreated]
-		onLivestreamStatusUpdated    WebhookEventCallback[EventLivestreamStatusUpdated]
+		verifier  *WebhookVerifier
+		// keySource provides public key fetched from the Kick's API, static publicKey is used if it's nil.
+		keySource *apiPublicKeySource
+
+		// maxEventAge is a maximum difference between the event's message timestamp and current time,
+		// check is disabled if it's zero.
+		maxEventAge time.Duration
+		now         func() time.Time
+
+		maxBodyBytes int64
+
+		// synchronous makes callbacks to be called before the response is sent, so their failures are
+		// reported to Kick.
+		synchronous bool
+		dispatcher  eventsDispatcher
+		// Dispatcher is built from the configuration once all the options are applied, ordered delivery takes
+		// precedence over the worker pool.
+		workerPool      *workerPoolConfig
+		orderedDelivery *OrderedDelivery
+		// orderingKey returns key of the event that ordered dispatcher serialises callbacks by.
+		orderingKey EventKeyFunc
+		// inFlight tracks events that are being handled, so Shutdown waits for callbacks called
+		// synchronously as well.
+		inFlight     sync.WaitGroup
+		closed       bool
+		closedLocker sync.RWMutex
+		onPanic      PanicHandler
+		// versionFallback handles events of the unsupported versions instead of rejecting them.
+		versionFallback EventVersionFallback
+
+		unknownEventPolicy UnknownEventPolicy
+		onUnknownEvent     UnknownEventCallback
+
+		decoders       map[eventKey]eventDecoder
+		decodersLocker sync.RWMutex
 	}
 )
 
@@ -46,8 +114,17 @@ func NewWebhookEventsHandler(options ...EventsHandlerOption) *WebhookEventsHandl
 	handler := &WebhookEventsHandler{
 		verify:    true,             // EventsResource verification is enabled by default.
 		publicKey: publickey.Static, // Static public key is a default public key.
+		now:       time.Now,
+
+		maxBodyBytes: defaultMaxBodyBytes,
+		onPanic:      defaultPanicHandler,
+
+		unknownEventPolicy: UnknownEventAck,
+		decoders:           make(map[eventKey]eventDecoder),
 	}
 
+	registerDefaultEvents(handler)
+
 	// Default events handler can be overridden by options.
 	handler.eventsHandler = handler.handleEvent
 
@@ -55,147 +132,350 @@ func NewWebhookEventsHandler(options ...EventsHandlerOption) *WebhookEventsHandl
 		option(handler)
 	}
 
+	switch {
+	case handler.orderedDelivery != nil:
+		handler.orderingKey = handler.orderedDelivery.Key
+		handler.dispatcher = newOrderedDispatcher(*handler.orderedDelivery)
+	case handler.workerPool != nil:
+		handler.dispatcher = newPoolDispatcher(
+			handler.workerPool.workers,
+			handler.workerPool.queueSize,
+			handler.workerPool.policy,
+		)
+	default:
+		handler.dispatcher = newGoroutineDispatcher()
+	}
+
+	// Public key is parsed once, events with invalid public key are rejected as unverified.
+	if handler.verifier == nil {
+		handler.verifier, _ = NewWebhookVerifier(handler.publicKey)
+	}
+
+	if handler.keySource != nil {
+		handler.keySource.now = handler.now
+
+		// Configured public key is used while the key can't be fetched from the Kick's API.
+		if handler.verifier != nil {
+			handler.keySource.fallback = handler.verifier.publicKey
+		}
+	}
+
 	return handler
 }
 
+// ServeHTTP handles webhook event. Rejected requests are responded with the following status codes:
+//
+//   - 405 Method Not Allowed (ErrMethodNotAllowed): request's method is not POST.
+//   - 415 Unsupported Media Type (ErrUnsupportedContentType): Content-Type is not application/json.
+//   - 400 Bad Request (ErrMissingEventHeader): Kick-Event-Message-Id, Kick-Event-Message-Timestamp or
+//     Kick-Event-Type header is missing, as well as Kick-Event-Signature if the verification is enabled.
+//   - 413 Request Entity Too Large (ErrBodyTooLarge): body exceeds the limit set with WithMaxBodyBytes.
+//   - 400 Bad Request (ErrInvalidEventTimestamp): message timestamp is malformed, checked only with WithMaxEventAge.
+//   - 422 Unprocessable Entity (ErrStaleEvent): message timestamp is outside of the window set with WithMaxEventAge.
+//   - 403 Forbidden (ErrInvalidSignature): event's signature can't be verified.
+//   - 503 Service Unavailable (ErrEventsQueueFull, ErrHandlerShutdown): event can't be accepted at the moment.
+//   - 500 Internal Server Error (ErrUnreadableBody or any other error): event can't be read or handled.
 func (weh *WebhookEventsHandler) ServeHTTP(w http.ResponseWriter, request *http.Request) {
-	if request.Method != http.MethodPost {
-		http.Error(w, "Method is not allowed", http.StatusMethodNotAllowed)
+	buffer := bodyBuffersPool.Get().(*bytes.Buffer)
+	defer releaseBodyBuffer(buffer)
+
+	if err := weh.serveEvent(w, request, buffer); err != nil {
+		statusCode, message := webhookErrorResponse(err)
+		http.Error(w, message, statusCode)
+
 		return
 	}
 
-	body, err := io.ReadAll(request.Body)
-	if err != nil {
-		http.Error(w, "Cannot read request body", http.StatusInternalServerError)
-		return
+	w.WriteHeader(http.StatusOK)
+}
+
+func (weh *WebhookEventsHandler) serveEvent(w http.ResponseWriter, request *http.Request, buffer *bytes.Buffer) error {
+	if request.Method != http.MethodPost {
+		return ErrMethodNotAllowed
+	}
+
+	if err := checkEventContentType(request.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+
+	header := ExtractWebhookEventHeader(request)
+
+	if err := weh.checkRequiredHeaders(header); err != nil {
+		return err
 	}
+
 	defer func() {
 		_ = request.Body.Close()
 	}()
 
-	header := ExtractWebhookEventHeader(request)
+	if _, err := buffer.ReadFrom(http.MaxBytesReader(w, request.Body, weh.maxBodyBytes)); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return ErrBodyTooLarge
+		}
+
+		return fmt.Errorf("%w: %w", ErrUnreadableBody, err)
+	}
+
+	body := buffer.Bytes()
+
+	if err := weh.checkEventAge(header); err != nil {
+		return err
+	}
 
 	if weh.verify {
-		if err = VerifyWebhookEvent(header, weh.publicKey, body); err != nil {
-			http.Error(w, "Cannot verify event", http.StatusForbidden)
-			return
+		if err := weh.verifyEvent(request.Context(), header, body); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+		}
+	}
+
+	return weh.handleEventSafely(request.Context(), header, body)
+}
+
+// webhookErrorResponse returns status code and message of the response to the rejected event.
+func webhookErrorResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed, "Method is not allowed"
+	case errors.Is(err, ErrUnsupportedContentType):
+		return http.StatusUnsupportedMediaType, "Content type is not supported"
+	case errors.Is(err, ErrMissingEventHeader):
+		return http.StatusBadRequest, "Missing event header"
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge, "Event body is too large"
+	case errors.Is(err, ErrUnreadableBody):
+		return http.StatusInternalServerError, "Cannot read request body"
+	case errors.Is(err, ErrInvalidEventTimestamp):
+		return http.StatusBadRequest, "Invalid event timestamp"
+	case errors.Is(err, ErrStaleEvent):
+		return http.StatusUnprocessableEntity, "Event is too old"
+	case errors.Is(err, ErrInvalidSignature):
+		return http.StatusForbidden, "Cannot verify event"
+	case errors.Is(err, ErrEventsQueueFull), errors.Is(err, ErrHandlerShutdown):
+		return http.StatusServiceUnavailable, "Cannot accept event"
+	default:
+		return http.StatusInternalServerError, "Cannot handle event"
+	}
+}
+
+func checkEventContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, contentType)
+	}
+
+	return nil
+}
+
+// checkRequiredHeaders rejects events without headers that are required to handle them. Empty version is
+// considered the first version of the event.
+func (weh *WebhookEventsHandler) checkRequiredHeaders(header WebhookEventHeader) error {
+	required := [...]struct {
+		name  string
+		value string
+		skip  bool
+	}{
+		{name: "Kick-Event-Message-Id", value: header.MessageID},
+		{name: "Kick-Event-Message-Timestamp", value: header.MessageTimestamp},
+		{name: "Kick-Event-Type", value: header.EventType},
+		{name: "Kick-Event-Signature", value: header.Signature, skip: !weh.verify},
+	}
+
+	for _, field := range required {
+		if !field.skip && len(field.value) == 0 {
+			return fmt.Errorf("%w: %s", ErrMissingEventHeader, field.name)
 		}
 	}
 
-	if err = weh.eventsHandler(request.Context(), header, body); err != nil {
-		http.Error(w, "Cannot handle event", http.StatusInternalServerError)
+	return nil
+}
+
+// verifyEvent verifies event signature with the configured public key. Key fetched from the Kick's API is
+// refetched once on failure, since Kick might have rotated it.
+func (weh *WebhookEventsHandler) verifyEvent(ctx context.Context, header WebhookEventHeader, body []byte) error {
+	if weh.keySource == nil {
+		return weh.verifier.Verify(header, body)
+	}
+
+	err := verifyWebhookEvent(header, weh.keySource.Key(ctx), body)
+	if err == nil {
+		return nil
+	}
+
+	if key, rotated := weh.keySource.Refetch(ctx); rotated {
+		return verifyWebhookEvent(header, key, body)
+	}
+
+	return err
+}
+
+func releaseBodyBuffer(buffer *bytes.Buffer) {
+	if buffer.Cap() > maxPooledBodyBufferSize {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	buffer.Reset()
+	bodyBuffersPool.Put(buffer)
+}
+
+// checkEventAge rejects events which message timestamp is outside of the allowed window, so captured
+// requests can't be replayed once the tracker forgets their IDs.
+func (weh *WebhookEventsHandler) checkEventAge(header WebhookEventHeader) error {
+	if weh.maxEventAge <= 0 {
+		return nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, header.MessageTimestamp)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEventTimestamp, err)
+	}
+
+	age := weh.now().Sub(timestamp)
+
+	if age > weh.maxEventAge || age < -weh.maxEventAge {
+		return ErrStaleEvent
+	}
+
+	return nil
 }
 
-func (weh *WebhookEventsHandler) handleEvent(ctx context.Context, header WebhookEventHeader, body []byte) error {
+func (weh *WebhookEventsHandler) handleEvent(
+	ctx context.Context,
+	header WebhookEventHeader,
+	body []byte,
+) (err error) {
+	weh.closedLocker.RLock()
+
+	if weh.closed {
+		weh.closedLocker.RUnlock()
+		return ErrHandlerShutdown
+	}
+
+	weh.inFlight.Add(1)
+	weh.closedLocker.RUnlock()
+
+	defer weh.inFlight.Done()
+
 	if weh.tracker != nil {
-		duplicate, err := weh.tracker.Track(ctx, header.MessageID)
-		if err != nil {
-			return fmt.Errorf("track event: %w", err)
+		duplicate, trackErr := weh.tracker.Track(ctx, header.MessageID)
+		if trackErr != nil {
+			return fmt.Errorf("track event: %w", trackErr)
 		}
 
 		if duplicate {
 			return nil
 		}
-	}
 
-	switch header.EventType {
-	case EventTypeChatMessage:
-		var event EventChatMessage
+		// Event that is not handled is forgotten, so its redelivery by Kick is not dropped as duplicate.
+		defer func() {
+			if err == nil {
+				return
+			}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+			if forgetter, ok := weh.tracker.(EventsForgetter); ok {
+				_ = forgetter.Forget(ctx, header.MessageID)
+			}
+		}()
 
-		if weh.onChatMessage != nil {
-			go weh.onChatMessage(header, event)
-		}
-	case EventTypeChannelFollow:
-		var event EventChannelFollow
+		// Panic is recovered before the event is forgotten, since it unwinds past the check above otherwise.
+		defer weh.recoverPanic(header, &err)
+	}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+	decoder, err := weh.decoder(header)
 
-		if weh.onChannelFollow != nil {
-			go weh.onChannelFollow(header, event)
-		}
-	case EventTypeChannelSubRenewal:
-		var event EventChannelSubscriptionRenewal
+	switch {
+	case errors.Is(err, ErrUnsupportedEventVersion) && weh.versionFallback != nil:
+		return weh.recoverDispatch(header, func(ctx context.Context) error {
+			return weh.versionFallback(ctx, header, bytes.Clone(body))
+		})(ctx)
+	case errors.Is(err, ErrUnexpectedEventType):
+		decoder, err = weh.unknownEventDecoder()
+	}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+	if err != nil {
+		return err
+	}
 
-		if weh.onChannelSubscriptionRenewal != nil {
-			go weh.onChannelSubscriptionRenewal(header, event)
-		}
-	case EventTypeChannelSubGifts:
-		var event EventChannelSubscriptionGifts
+	dispatch, err := decoder(header, body)
+	if err != nil {
+		return err
+	}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+	if dispatch == nil {
+		return nil
+	}
 
-		if weh.onChannelSubscriptionGifts != nil {
-			go weh.onChannelSubscriptionGifts(header, event)
-		}
-	case EventTypeChannelSubCreated:
-		var event EventChannelSubscriptionCreated
+	dispatch = weh.recoverDispatch(header, dispatch)
+
+	if !weh.synchronous {
+		err = weh.dispatcher.Dispatch(ctx, eventTask{
+			ctx:      context.WithoutCancel(ctx),
+			header:   header,
+			key:      weh.eventKey(header, body),
+			dispatch: dispatch,
+		})
+	} else if err = dispatch(ctx); err != nil {
+		err = fmt.Errorf("event callback: %w", err)
+	}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+	return err
+}
 
-		if weh.onChannelSubscriptionCreated != nil {
-			go weh.onChannelSubscriptionCreated(header, event)
-		}
-	case EventTypeLivestreamStatusUpdated:
-		var event EventLivestreamStatusUpdated
+func (weh *WebhookEventsHandler) eventKey(header WebhookEventHeader, body []byte) string {
+	if weh.orderingKey == nil {
+		return ""
+	}
 
-		if err := json.Unmarshal(body, &event); err != nil {
-			return fmt.Errorf("unmarshal event body: %w", err)
-		}
+	return weh.orderingKey(header, body)
+}
 
-		if weh.onLivestreamStatusUpdated != nil {
-			go weh.onLivestreamStatusUpdated(header, event)
-		}
-	default:
-		return ErrUnexpectedEventType
+// Shutdown stops accepting events and waits until callbacks of the already accepted events are finished or
+// the context is done. Events received after the shutdown are rejected with 503 status code.
+func (weh *WebhookEventsHandler) Shutdown(ctx context.Context) error {
+	weh.closedLocker.Lock()
+	weh.closed = true
+	weh.closedLocker.Unlock()
+
+	if err := waitGroupWithContext(ctx, &weh.inFlight); err != nil {
+		return err
 	}
 
-	return nil
+	return weh.dispatcher.Shutdown(ctx)
+}
+
+// OnUnknownEvent sets callback for the events of the types that have no registered payloads and sets unknown events
+// policy to UnknownEventForward. Callback is called the same way as callbacks of the known events.
+func (weh *WebhookEventsHandler) OnUnknownEvent(cb UnknownEventCallback) {
+	weh.onUnknownEvent = cb
+	weh.unknownEventPolicy = UnknownEventForward
 }
 
 func (weh *WebhookEventsHandler) OnChatMessage(cb WebhookEventCallback[EventChatMessage]) {
-	weh.onChatMessage = cb
+	On(weh, EventTypeChatMessage, 1, cb)
 }
 
 func (weh *WebhookEventsHandler) OnChannelFollow(cb WebhookEventCallback[EventChannelFollow]) {
-	weh.onChannelFollow = cb
+	On(weh, EventTypeChannelFollow, 1, cb)
 }
 
 func (weh *WebhookEventsHandler) OnChannelSubscriptionGifts(cb WebhookEventCallback[EventChannelSubscriptionGifts]) {
-	weh.onChannelSubscriptionGifts = cb
+	On(weh, EventTypeChannelSubGifts, 1, cb)
 }
 
 func (weh *WebhookEventsHandler) OnLivestreamStatusUpdated(
 	cb WebhookEventCallback[EventLivestreamStatusUpdated],
 ) {
-	weh.onLivestreamStatusUpdated = cb
+	On(weh, EventTypeLivestreamStatusUpdated, 1, cb)
 }
 
 func (weh *WebhookEventsHandler) OnChannelSubscriptionRenewal(
 	cb WebhookEventCallback[EventChannelSubscriptionRenewal],
 ) {
-	weh.onChannelSubscriptionRenewal = cb
+	On(weh, EventTypeChannelSubRenewal, 1, cb)
 }
 
 func (weh *WebhookEventsHandler) OnChannelSubscriptionCreated(
 	cb WebhookEventCallback[EventChannelSubscriptionCreated],
 ) {
-	weh.onChannelSubscriptionCreated = cb
+	On(weh, EventTypeChannelSubCreated, 1, cb)
 }
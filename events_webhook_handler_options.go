@@ -1,5 +1,11 @@
 package kicksdk
 
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
 type EventsHandlerOption func(*WebhookEventsHandler)
 
 func WithEventsTracker(tracker EventsTracker) EventsHandlerOption {
@@ -10,7 +16,10 @@ func WithEventsTracker(tracker EventsTracker) EventsHandlerOption {
 
 func WithEventsHandler(eventsHandler WebhookEventHandlerFunc) EventsHandlerOption {
 	return func(handler *WebhookEventsHandler) {
-		handler.eventsHandler = eventsHandler
+		// Body is read into the pooled buffer, so the handler receives its own copy that it can retain.
+		handler.eventsHandler = func(ctx context.Context, header WebhookEventHeader, body []byte) error {
+			return eventsHandler(ctx, header, bytes.Clone(body))
+		}
 	}
 }
 
@@ -25,3 +34,105 @@ func WithPublicKey(publicKey string) EventsHandlerOption {
 		handler.publicKey = publicKey
 	}
 }
+
+// WithSynchronousCallbacks makes handler call event callbacks before responding, so the response is sent only after
+// the event is processed. Callbacks registered with OnContext that return an error make handler respond with
+// 500 status code, so Kick redelivers the event and it's processed at least once.
+func WithSynchronousCallbacks() EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.synchronous = true
+	}
+}
+
+// WithWorkerPool makes handler run callbacks on the fixed number of workers instead of a goroutine per event.
+// Accepted events wait for the workers in the queue of the provided size, overflow policy defines what happens
+// when it's full, OverflowBlock by default. It's ignored if WithOrderedDelivery is set as well.
+func WithWorkerPool(workers, queueSize int, policy OverflowPolicy) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.workerPool = &workerPoolConfig{workers: workers, queueSize: queueSize, policy: policy}
+	}
+}
+
+// WithOrderedDelivery makes handler call callbacks of the events with the same key (broadcaster's user ID by default)
+// one by one in order of their arrival, while events with different keys are still processed in parallel.
+// Optional reorder window makes events to be delivered in order of their message timestamps. It takes precedence
+// over WithWorkerPool regardless of the order of options.
+func WithOrderedDelivery(config OrderedDelivery) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		if config.Key == nil {
+			config.Key = BroadcasterEventKey
+		}
+
+		handler.orderedDelivery = &config
+	}
+}
+
+// WithPanicHandler sets handler of the panics recovered in the event callbacks and events handler. By default,
+// panics are logged with the standard logger.
+func WithPanicHandler(panicHandler PanicHandler) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.onPanic = panicHandler
+	}
+}
+
+// WithEventVersionFallback sets fallback that handles events which type is known, but version is not registered.
+// Otherwise, such events are rejected with ErrUnsupportedEventVersion.
+func WithEventVersionFallback(fallback EventVersionFallback) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.versionFallback = fallback
+	}
+}
+
+// WithUnknownEventPolicy sets how events of the types that have no registered payloads are handled,
+// UnknownEventAck by default.
+func WithUnknownEventPolicy(policy UnknownEventPolicy) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.unknownEventPolicy = policy
+	}
+}
+
+// WithWebhookVerifier sets verifier used for the events verification instead of the one created with
+// the public key.
+func WithWebhookVerifier(verifier *WebhookVerifier) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.verifier = verifier
+	}
+}
+
+// WithMaxBodyBytes sets maximum size of the event body in bytes, 1 MiB by default or if it's not positive.
+// Larger events are rejected with 413 status code before they are read entirely.
+func WithMaxBodyBytes(size int64) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		if size <= 0 {
+			size = defaultMaxBodyBytes
+		}
+
+		handler.maxBodyBytes = size
+	}
+}
+
+// WithPublicKeyFromAPI enables verification of events with the public key obtained from the Kick's API with
+// Client.PublicKey. Key is cached and refreshed in the background with the provided interval (1 hour if it's
+// zero), and refetched on the verification failure to handle its rotation. Public key set with WithPublicKey
+// (or the static one) is used while the key is being fetched or Kick's API is unreachable, so events aren't
+// delayed by the slow API. Every fetch is limited to 10 seconds.
+func WithPublicKeyFromAPI(client *Client, refreshInterval time.Duration) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.keySource = newAPIPublicKeySource(client, refreshInterval)
+	}
+}
+
+// WithMaxEventAge enables rejection of events which message timestamp differs from the current time by more
+// than the provided tolerance, in either direction.
+func WithMaxEventAge(tolerance time.Duration) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.maxEventAge = tolerance
+	}
+}
+
+// WithEventsClock sets function that returns current time.
+func WithEventsClock(now func() time.Time) EventsHandlerOption {
+	return func(handler *WebhookEventsHandler) {
+		handler.now = now
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +30,37 @@ func (m *mockWebhookEventHandler) handleEvent(ctx context.Context, header Webhoo
 	return args.Error(0)
 }
 
+// mockEventHeader contains all the headers required by the WebhookEventsHandler.
+var mockEventHeader = WebhookEventHeader{
+	MessageID:        "message-id",
+	SubscriptionID:   "subscription-id",
+	Signature:        "signature",
+	MessageTimestamp: "2025-01-01T00:00:00Z",
+	EventType:        EventTypeChatMessage,
+	EventVersion:     "1",
+}
+
+// newMockEventRequest creates webhook event request with non-empty headers of the event header.
+func newMockEventRequest(body io.Reader, header WebhookEventHeader) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/", body)
+	request.Header.Set("Content-Type", "application/json")
+
+	for name, value := range map[string]string{
+		"Kick-Event-Message-Id":        header.MessageID,
+		"Kick-Event-Subscription-Id":   header.SubscriptionID,
+		"Kick-Event-Signature":         header.Signature,
+		"Kick-Event-Message-Timestamp": header.MessageTimestamp,
+		"Kick-Event-Type":              header.EventType,
+		"Kick-Event-Version":           header.EventVersion,
+	} {
+		if len(value) != 0 {
+			request.Header.Set(name, value)
+		}
+	}
+
+	return request
+}
+
 func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 	t.Parallel()
 
@@ -45,7 +79,7 @@ func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 
 	t.Run("Request with invalid body", func(t *testing.T) {
 		var (
-			request  = httptest.NewRequest(http.MethodPost, "/", &mockErrReader{})
+			request  = newMockEventRequest(&mockErrReader{}, mockEventHeader)
 			recorder = httptest.NewRecorder()
 		)
 
@@ -58,7 +92,7 @@ func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 
 	t.Run("Request with failed verification", func(t *testing.T) {
 		var (
-			request  = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("test"))
+			request  = newMockEventRequest(bytes.NewBufferString("test"), mockEventHeader)
 			recorder = httptest.NewRecorder()
 		)
 
@@ -79,11 +113,10 @@ func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 			)
 		)
 
-		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("test"))
-		request = request.WithContext(context.WithValue(request.Context(), "header", WebhookEventHeader{}))
+		request := newMockEventRequest(bytes.NewBufferString("test"), mockEventHeader)
 
 		mockHandler.
-			On("handleEvent", mock.Anything, WebhookEventHeader{}, []byte("test")).
+			On("handleEvent", mock.Anything, mockEventHeader, []byte("test")).
 			Return(errors.New(""))
 
 		handler.ServeHTTP(recorder, request)
@@ -103,11 +136,10 @@ func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 			)
 		)
 
-		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString("test"))
-		request = request.WithContext(context.WithValue(request.Context(), "header", WebhookEventHeader{}))
+		request := newMockEventRequest(bytes.NewBufferString("test"), mockEventHeader)
 
 		mockHandler.
-			On("handleEvent", mock.Anything, WebhookEventHeader{}, []byte("test")).
+			On("handleEvent", mock.Anything, mockEventHeader, []byte("test")).
 			Return(nil)
 
 		handler.ServeHTTP(recorder, request)
@@ -116,4 +148,437 @@ func TestWebhookEventsHandler_ServeHTTP(t *testing.T) {
 		assert.Equal(t, "", recorder.Body.String())
 		mockHandler.AssertExpectations(t)
 	})
+
+	t.Run("Request with stale event", func(t *testing.T) {
+		var (
+			now      = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+			recorder = httptest.NewRecorder()
+			handler  = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithMaxEventAge(5*time.Minute),
+				WithEventsClock(func() time.Time { return now }),
+			)
+		)
+
+		header := mockEventHeader
+		header.MessageTimestamp = now.Add(-time.Hour).Format(time.RFC3339)
+
+		request := newMockEventRequest(bytes.NewBufferString("test"), header)
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, "Event is too old\n", recorder.Body.String())
+	})
+
+	t.Run("Request with invalid event timestamp", func(t *testing.T) {
+		var (
+			recorder = httptest.NewRecorder()
+			handler  = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithMaxEventAge(5*time.Minute),
+			)
+		)
+
+		header := mockEventHeader
+		header.MessageTimestamp = "yesterday"
+
+		request := newMockEventRequest(bytes.NewBufferString("test"), header)
+
+		handler.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, "Invalid event timestamp\n", recorder.Body.String())
+	})
+}
+
+func TestWebhookEventsHandler_RejectedRequests(t *testing.T) {
+	t.Parallel()
+
+	withoutHeader := func(modify func(*WebhookEventHeader)) WebhookEventHeader {
+		header := mockEventHeader
+		modify(&header)
+
+		return header
+	}
+
+	tests := []struct {
+		name            string
+		request         *http.Request
+		options         []EventsHandlerOption
+		expectedCode    int
+		expectedMessage string
+	}{
+		{
+			name: "Missing content type",
+			request: func() *http.Request {
+				request := newMockEventRequest(bytes.NewBufferString("{}"), mockEventHeader)
+				request.Header.Del("Content-Type")
+
+				return request
+			}(),
+			expectedCode:    http.StatusUnsupportedMediaType,
+			expectedMessage: "Content type is not supported",
+		},
+		{
+			name: "Unexpected content type",
+			request: func() *http.Request {
+				request := newMockEventRequest(bytes.NewBufferString("{}"), mockEventHeader)
+				request.Header.Set("Content-Type", "text/plain")
+
+				return request
+			}(),
+			expectedCode:    http.StatusUnsupportedMediaType,
+			expectedMessage: "Content type is not supported",
+		},
+		{
+			name: "Missing message ID",
+			request: newMockEventRequest(
+				bytes.NewBufferString("{}"),
+				withoutHeader(func(header *WebhookEventHeader) { header.MessageID = "" }),
+			),
+			options:         []EventsHandlerOption{WithDisabledEventsVerification()},
+			expectedCode:    http.StatusBadRequest,
+			expectedMessage: "Missing event header",
+		},
+		{
+			name: "Missing event type",
+			request: newMockEventRequest(
+				bytes.NewBufferString("{}"),
+				withoutHeader(func(header *WebhookEventHeader) { header.EventType = "" }),
+			),
+			options:         []EventsHandlerOption{WithDisabledEventsVerification()},
+			expectedCode:    http.StatusBadRequest,
+			expectedMessage: "Missing event header",
+		},
+		{
+			name: "Missing signature",
+			request: newMockEventRequest(
+				bytes.NewBufferString("{}"),
+				withoutHeader(func(header *WebhookEventHeader) { header.Signature = "" }),
+			),
+			expectedCode:    http.StatusBadRequest,
+			expectedMessage: "Missing event header",
+		},
+		{
+			name:            "Too large body",
+			request:         newMockEventRequest(bytes.NewBufferString("{}"), mockEventHeader),
+			options:         []EventsHandlerOption{WithDisabledEventsVerification(), WithMaxBodyBytes(1)},
+			expectedCode:    http.StatusRequestEntityTooLarge,
+			expectedMessage: "Event body is too large",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			NewWebhookEventsHandler(test.options...).ServeHTTP(recorder, test.request)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedMessage+"\n", recorder.Body.String())
+		})
+	}
+
+	t.Run("Default body limit", func(t *testing.T) {
+		for _, size := range []int64{0, -1} {
+			var (
+				recorder = httptest.NewRecorder()
+				request  = newMockEventRequest(bytes.NewBufferString("{}"), mockEventHeader)
+				handler  = NewWebhookEventsHandler(WithDisabledEventsVerification(), WithMaxBodyBytes(size))
+			)
+
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, int64(defaultMaxBodyBytes), handler.maxBodyBytes)
+		}
+	})
+
+	t.Run("Signature is not required without verification", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request := newMockEventRequest(
+			bytes.NewBufferString("{}"),
+			withoutHeader(func(header *WebhookEventHeader) { header.Signature, header.EventVersion = "", "" }),
+		)
+		request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		NewWebhookEventsHandler(WithDisabledEventsVerification()).ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestWebhookErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	for err, expectedCode := range map[error]int{
+		ErrMethodNotAllowed:       http.StatusMethodNotAllowed,
+		ErrUnsupportedContentType: http.StatusUnsupportedMediaType,
+		ErrMissingEventHeader:     http.StatusBadRequest,
+		ErrBodyTooLarge:           http.StatusRequestEntityTooLarge,
+		ErrUnreadableBody:         http.StatusInternalServerError,
+		ErrInvalidEventTimestamp:  http.StatusBadRequest,
+		ErrStaleEvent:             http.StatusUnprocessableEntity,
+		ErrInvalidSignature:       http.StatusForbidden,
+		ErrEventsQueueFull:        http.StatusServiceUnavailable,
+		ErrHandlerShutdown:        http.StatusServiceUnavailable,
+		fmt.Errorf("event callback: %w", ErrCallbackPanic): http.StatusInternalServerError,
+	} {
+		statusCode, _ := webhookErrorResponse(err)
+		assert.Equal(t, expectedCode, statusCode, err.Error())
+	}
+}
+
+func TestWebhookEventsHandler_CheckEventAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	handler := NewWebhookEventsHandler(
+		WithMaxEventAge(time.Minute),
+		WithEventsClock(func() time.Time { return now }),
+	)
+
+	tests := []struct {
+		name        string
+		timestamp   string
+		expectedErr error
+	}{
+		{name: "Fresh event", timestamp: "2024-12-31T23:59:30Z"},
+		{name: "Event with fractional seconds", timestamp: "2024-12-31T23:59:30.123456Z"},
+		{name: "Stale event", timestamp: "2024-12-31T23:58:00Z", expectedErr: ErrStaleEvent},
+		{name: "Event from the future", timestamp: "2025-01-01T00:02:00Z", expectedErr: ErrStaleEvent},
+		{name: "Malformed timestamp", timestamp: "", expectedErr: ErrInvalidEventTimestamp},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := handler.checkEventAge(WebhookEventHeader{MessageTimestamp: test.timestamp})
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	err := NewWebhookEventsHandler().checkEventAge(WebhookEventHeader{})
+	assert.NoError(t, err)
+}
+
+func TestWebhookEventsHandler_SynchronousCallbacks(t *testing.T) {
+	t.Parallel()
+
+	serve := func(handler *WebhookEventsHandler) int {
+		var (
+			recorder = httptest.NewRecorder()
+			request  = newMockEventRequest(bytes.NewBufferString(`{"message_id": "id"}`), mockEventHeader)
+		)
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	var (
+		tracker = NewMapEventsTracker()
+		handler = NewWebhookEventsHandler(
+			WithDisabledEventsVerification(),
+			WithSynchronousCallbacks(),
+			WithEventsTracker(tracker),
+		)
+		calls  int
+		failed = true
+	)
+
+	OnContext(handler, EventTypeChatMessage, 1, func(ctx context.Context, _ WebhookEventHeader, _ EventChatMessage) error {
+		calls++
+
+		if failed {
+			return errors.New("database is unavailable")
+		}
+
+		return ctx.Err()
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, serve(handler))
+
+	// Redelivery of the failed event is not dropped as duplicate.
+	failed = false
+
+	assert.Equal(t, http.StatusOK, serve(handler))
+	assert.Equal(t, http.StatusOK, serve(handler))
+	assert.Equal(t, 2, calls)
+}
+
+func TestWebhookEventsHandler_RetainedBody(t *testing.T) {
+	t.Parallel()
+
+	serve := func(handler *WebhookEventsHandler, header WebhookEventHeader, body string) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newMockEventRequest(bytes.NewBufferString(body), header))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	t.Run("Events handler", func(t *testing.T) {
+		var bodies [][]byte
+
+		handler := NewWebhookEventsHandler(
+			WithDisabledEventsVerification(),
+			WithEventsHandler(func(_ context.Context, _ WebhookEventHeader, body []byte) error {
+				bodies = append(bodies, body)
+				return nil
+			}),
+		)
+
+		serve(handler, mockEventHeader, `{"message_id": "first"}`)
+		serve(handler, mockEventHeader, `{"message_id": "second"}`)
+
+		assert.Equal(t, `{"message_id": "first"}`, string(bodies[0]))
+		assert.Equal(t, `{"message_id": "second"}`, string(bodies[1]))
+	})
+
+	t.Run("Version fallback", func(t *testing.T) {
+		var bodies [][]byte
+
+		handler := NewWebhookEventsHandler(
+			WithDisabledEventsVerification(),
+			WithEventVersionFallback(func(_ context.Context, _ WebhookEventHeader, body []byte) error {
+				bodies = append(bodies, body)
+				return nil
+			}),
+		)
+
+		header := mockEventHeader
+		header.EventVersion = "42"
+
+		serve(handler, header, `{"message_id": "first"}`)
+		serve(handler, header, `{"message_id": "second"}`)
+
+		assert.Equal(t, `{"message_id": "first"}`, string(bodies[0]))
+		assert.Equal(t, `{"message_id": "second"}`, string(bodies[1]))
+	})
+}
+
+func TestWebhookEventsHandler_ForgetUnhandledEvents(t *testing.T) {
+	t.Parallel()
+
+	serve := func(handler *WebhookEventsHandler, header WebhookEventHeader, body string) int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newMockEventRequest(bytes.NewBufferString(body), header))
+
+		return recorder.Code
+	}
+
+	t.Run("Malformed body", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithSynchronousCallbacks(),
+				WithEventsTracker(NewMapEventsTracker()),
+			)
+			calls int
+		)
+
+		handler.OnChatMessage(func(WebhookEventHeader, EventChatMessage) {
+			calls++
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, mockEventHeader, `{"message_id":`))
+
+		// Redelivery of the event that failed to decode is handled.
+		assert.Equal(t, http.StatusOK, serve(handler, mockEventHeader, `{"message_id": "id"}`))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Rejected unknown event", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithEventsTracker(NewMapEventsTracker()),
+				WithUnknownEventPolicy(UnknownEventReject),
+			)
+			header = mockEventHeader
+		)
+
+		header.EventType = "channel.reward.redeemed"
+
+		// Redelivery is rejected as well instead of being acknowledged as duplicate.
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{}`))
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{}`))
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithEventsTracker(NewMapEventsTracker()),
+			)
+			header = mockEventHeader
+		)
+
+		header.EventVersion = "42"
+
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{}`))
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{}`))
+	})
+
+	t.Run("Panicking version fallback", func(t *testing.T) {
+		var (
+			calls   int
+			handler = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithEventsTracker(NewMapEventsTracker()),
+				WithPanicHandler(func(PanicInfo) {}),
+				WithEventVersionFallback(func(context.Context, WebhookEventHeader, []byte) error {
+					if calls++; calls == 1 {
+						panic("fallback is broken")
+					}
+
+					return nil
+				}),
+			)
+			header = mockEventHeader
+		)
+
+		header.EventVersion = "42"
+
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{}`))
+		assert.Equal(t, http.StatusOK, serve(handler, header, `{}`))
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Panicking payload decoding", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler(
+				WithDisabledEventsVerification(),
+				WithSynchronousCallbacks(),
+				WithEventsTracker(NewMapEventsTracker()),
+				WithPanicHandler(func(PanicInfo) {}),
+			)
+			header = mockEventHeader
+			calls  int
+		)
+
+		header.EventType = "custom.event"
+
+		On(handler, header.EventType, 1, func(WebhookEventHeader, panickingPayload) {
+			calls++
+		})
+
+		assert.Equal(t, http.StatusInternalServerError, serve(handler, header, `{"panic": true}`))
+
+		// Redelivery of the event that panicked while decoding is handled.
+		assert.Equal(t, http.StatusOK, serve(handler, header, `{}`))
+		assert.Equal(t, 1, calls)
+	})
+}
+
+// panickingPayload panics while decoding bodies that request it.
+type panickingPayload struct{}
+
+func (panickingPayload) UnmarshalJSON(data []byte) error {
+	if bytes.Contains(data, []byte("panic")) {
+		panic("payload is broken")
+	}
+
+	return nil
 }
@@ -0,0 +1,74 @@
+package kicksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+)
+
+var ErrCallbackPanic = errors.New("event callback panicked")
+
+type (
+	// PanicInfo describes panic recovered while the event was handled.
+	PanicInfo struct {
+		EventType string
+		MessageID string
+		// Value is a value passed to panic.
+		Value any
+		Stack []byte
+	}
+
+	// PanicHandler is called with the panic recovered in the event's callback or events handler.
+	PanicHandler func(PanicInfo)
+)
+
+// defaultPanicHandler logs the panic with the standard logger.
+func defaultPanicHandler(info PanicInfo) {
+	log.Printf(
+		"kicksdk: panic while handling %q event %q: %v\n%s",
+		info.EventType,
+		info.MessageID,
+		info.Value,
+		info.Stack,
+	)
+}
+
+// recoverPanic must be deferred directly, it reports recovered panic to the panic handler and replaces
+// the returned error with ErrCallbackPanic.
+func (weh *WebhookEventsHandler) recoverPanic(header WebhookEventHeader, err *error) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	weh.onPanic(PanicInfo{
+		EventType: header.EventType,
+		MessageID: header.MessageID,
+		Value:     value,
+		Stack:     debug.Stack(),
+	})
+
+	*err = fmt.Errorf("%w: %v", ErrCallbackPanic, value)
+}
+
+// recoverDispatch wraps dispatch so its panic is recovered.
+func (weh *WebhookEventsHandler) recoverDispatch(header WebhookEventHeader, dispatch eventDispatch) eventDispatch {
+	return func(ctx context.Context) (err error) {
+		defer weh.recoverPanic(header, &err)
+
+		return dispatch(ctx)
+	}
+}
+
+// handleEventSafely calls events handler and recovers its panic.
+func (weh *WebhookEventsHandler) handleEventSafely(
+	ctx context.Context,
+	header WebhookEventHeader,
+	body []byte,
+) (err error) {
+	defer weh.recoverPanic(header, &err)
+
+	return weh.eventsHandler(ctx, header, body)
+}
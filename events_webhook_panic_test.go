@@ -0,0 +1,104 @@
+package kicksdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookEventsHandler_PanicHandler(t *testing.T) {
+	t.Parallel()
+
+	serve := func(handler *WebhookEventsHandler) int {
+		var (
+			recorder = httptest.NewRecorder()
+			header   = mockEventHeader
+		)
+
+		header.EventType = EventTypeChannelFollow
+		request := newMockEventRequest(strings.NewReader(`{}`), header)
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	assertPanic := func(t *testing.T, panics <-chan PanicInfo) {
+		t.Helper()
+
+		info := receive(t, panics)
+
+		assert.Equal(t, EventTypeChannelFollow, info.EventType)
+		assert.Equal(t, "message-id", info.MessageID)
+		assert.Equal(t, "callback is broken", info.Value)
+		assert.NotEmpty(t, info.Stack)
+	}
+
+	newHandler := func(panics chan<- PanicInfo, options ...EventsHandlerOption) *WebhookEventsHandler {
+		options = append(
+			options,
+			WithDisabledEventsVerification(),
+			WithPanicHandler(func(info PanicInfo) { panics <- info }),
+		)
+
+		handler := NewWebhookEventsHandler(options...)
+
+		handler.OnChannelFollow(func(WebhookEventHeader, EventChannelFollow) {
+			panic("callback is broken")
+		})
+
+		return handler
+	}
+
+	t.Run("Asynchronous callback", func(t *testing.T) {
+		panics := make(chan PanicInfo, 1)
+
+		handler := newHandler(panics)
+
+		assert.Equal(t, http.StatusOK, serve(handler))
+		assertPanic(t, panics)
+		assert.NoError(t, handler.Shutdown(context.Background()))
+	})
+
+	t.Run("Worker pool callback", func(t *testing.T) {
+		panics := make(chan PanicInfo, 2)
+
+		handler := newHandler(panics, WithWorkerPool(1, 1, OverflowBlock))
+
+		// Worker survives the panic and handles the next event.
+		assert.Equal(t, http.StatusOK, serve(handler))
+		assert.Equal(t, http.StatusOK, serve(handler))
+		assertPanic(t, panics)
+		assertPanic(t, panics)
+		assert.NoError(t, handler.Shutdown(context.Background()))
+	})
+
+	t.Run("Synchronous callback", func(t *testing.T) {
+		panics := make(chan PanicInfo, 1)
+
+		assert.Equal(t, http.StatusInternalServerError, serve(newHandler(panics, WithSynchronousCallbacks())))
+		assertPanic(t, panics)
+
+		select {
+		case <-panics:
+			t.Fatal("panic is reported twice")
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+
+	t.Run("Events handler", func(t *testing.T) {
+		panics := make(chan PanicInfo, 1)
+
+		handler := newHandler(panics, WithEventsHandler(func(context.Context, WebhookEventHeader, []byte) error {
+			panic("callback is broken")
+		}))
+
+		assert.Equal(t, http.StatusInternalServerError, serve(handler))
+		assertPanic(t, panics)
+	})
+}
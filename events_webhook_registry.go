@@ -0,0 +1,178 @@
+package kicksdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// defaultEventVersion is a version of events which version header is empty.
+const defaultEventVersion = 1
+
+// UnknownEventPolicy defines how events of the types that have no registered payloads are handled.
+type UnknownEventPolicy int
+
+const (
+	// UnknownEventAck acknowledges unknown events without handling them, so Kick doesn't redeliver them.
+	UnknownEventAck UnknownEventPolicy = iota + 1
+	// UnknownEventReject rejects unknown events with ErrUnexpectedEventType.
+	UnknownEventReject
+	// UnknownEventForward passes unknown events to the callback set with OnUnknownEvent, and acknowledges
+	// them if there is no callback.
+	UnknownEventForward
+)
+
+type (
+	// eventKey identifies payload of the event by its type and version.
+	eventKey struct {
+		eventType EventType
+		version   int
+	}
+
+	// eventDispatch calls the registered callback with already decoded event.
+	eventDispatch func(ctx context.Context) error
+
+	// eventDecoder decodes event body and returns function that dispatches it to the registered callback,
+	// or nil if there is no callback.
+	eventDecoder func(header WebhookEventHeader, body []byte) (eventDispatch, error)
+)
+
+// On registers callback for events with the provided type and version. Event body is decoded into the Payload,
+// so custom payload structs can be registered for the event types that SDK doesn't know yet. Callback registered
+// earlier for the same type and version is replaced.
+func On[Payload any](
+	handler *WebhookEventsHandler,
+	eventType EventType,
+	version int,
+	cb WebhookEventCallback[Payload],
+) {
+	var contextCallback WebhookEventContextCallback[Payload]
+
+	if cb != nil {
+		contextCallback = func(_ context.Context, header WebhookEventHeader, payload Payload) error {
+			cb(header, payload)
+			return nil
+		}
+	}
+
+	OnContext(handler, eventType, version, contextCallback)
+}
+
+// OnContext is like On, but registers callback that receives request's context and can return an error. Returned
+// error makes the handler respond with 500 status code only in the synchronous mode (see WithSynchronousCallbacks),
+// so Kick redelivers the event.
+func OnContext[Payload any](
+	handler *WebhookEventsHandler,
+	eventType EventType,
+	version int,
+	cb WebhookEventContextCallback[Payload],
+) {
+	handler.register(eventType, version, func(header WebhookEventHeader, body []byte) (eventDispatch, error) {
+		var payload Payload
+
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("unmarshal event body: %w", err)
+		}
+
+		if cb == nil {
+			return nil, nil
+		}
+
+		return func(ctx context.Context) error {
+			return cb(ctx, header, payload)
+		}, nil
+	})
+}
+
+// registerDefaultEvents registers payloads of the events known by the SDK without callbacks.
+func registerDefaultEvents(handler *WebhookEventsHandler) {
+	On[EventChatMessageV1](handler, EventTypeChatMessage, 1, nil)
+	On[EventChannelFollowV1](handler, EventTypeChannelFollow, 1, nil)
+	On[EventChannelSubscriptionRenewalV1](handler, EventTypeChannelSubRenewal, 1, nil)
+	On[EventChannelSubscriptionGiftsV1](handler, EventTypeChannelSubGifts, 1, nil)
+	On[EventChannelSubscriptionCreatedV1](handler, EventTypeChannelSubCreated, 1, nil)
+	On[EventLivestreamStatusUpdatedV1](handler, EventTypeLivestreamStatusUpdated, 1, nil)
+}
+
+func (weh *WebhookEventsHandler) register(eventType EventType, version int, decoder eventDecoder) {
+	weh.decodersLocker.Lock()
+	defer weh.decodersLocker.Unlock()
+
+	weh.decoders[eventKey{eventType: eventType, version: version}] = decoder
+}
+
+// decoder returns decoder registered for the event's type and version. ErrUnsupportedEventVersion is returned
+// if the event's type is registered, but with other versions only.
+func (weh *WebhookEventsHandler) decoder(header WebhookEventHeader) (eventDecoder, error) {
+	weh.decodersLocker.RLock()
+	defer weh.decodersLocker.RUnlock()
+
+	version, err := parseEventVersion(header.EventVersion)
+	if err != nil {
+		if !weh.typeRegistered(header.EventType) {
+			return nil, ErrUnexpectedEventType
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedEventVersion, err)
+	}
+
+	decoder, registered := weh.decoders[eventKey{eventType: header.EventType, version: version}]
+	if registered {
+		return decoder, nil
+	}
+
+	if weh.typeRegistered(header.EventType) {
+		return nil, fmt.Errorf("%w: %s version %d", ErrUnsupportedEventVersion, header.EventType, version)
+	}
+
+	return nil, ErrUnexpectedEventType
+}
+
+// unknownEventDecoder returns decoder of the unknown events according to the unknown events policy.
+func (weh *WebhookEventsHandler) unknownEventDecoder() (eventDecoder, error) {
+	if weh.unknownEventPolicy == UnknownEventReject {
+		return nil, ErrUnexpectedEventType
+	}
+
+	cb := weh.onUnknownEvent
+	if weh.unknownEventPolicy != UnknownEventForward || cb == nil {
+		return func(WebhookEventHeader, []byte) (eventDispatch, error) {
+			return nil, nil
+		}, nil
+	}
+
+	return func(header WebhookEventHeader, body []byte) (eventDispatch, error) {
+		// Body is copied, since its buffer is reused before asynchronous callback is called.
+		body = bytes.Clone(body)
+
+		return func(ctx context.Context) error {
+			return cb(ctx, header, body)
+		}, nil
+	}, nil
+}
+
+// typeRegistered reports whether any version of the event type is registered, decoders must be locked.
+func (weh *WebhookEventsHandler) typeRegistered(eventType EventType) bool {
+	for key := range weh.decoders {
+		if key.eventType == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseEventVersion(version string) (int, error) {
+	if len(version) == 0 {
+		return defaultEventVersion, nil
+	}
+
+	parsed, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, fmt.Errorf("parse event version: %w", err)
+	}
+
+	return parsed, nil
+}
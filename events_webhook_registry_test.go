@@ -0,0 +1,205 @@
+package kicksdk
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockCustomEvent struct {
+	Reward string `json:"reward"`
+}
+
+func receive[Payload any](t *testing.T, events <-chan Payload) Payload {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("event is not dispatched")
+	}
+
+	var zero Payload
+
+	return zero
+}
+
+func TestOn(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default event", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler()
+			events  = make(chan EventChatMessage, 1)
+		)
+
+		handler.OnChatMessage(func(_ WebhookEventHeader, event EventChatMessage) {
+			events <- event
+		})
+
+		err := handler.handleEvent(
+			context.Background(),
+			WebhookEventHeader{EventType: EventTypeChatMessage},
+			[]byte(`{"message_id": "message-id"}`),
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "message-id", receive(t, events).MessageID)
+	})
+
+	t.Run("Custom event", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler()
+			events  = make(chan mockCustomEvent, 1)
+		)
+
+		On(handler, "channel.reward.redeemed", 2, func(_ WebhookEventHeader, event mockCustomEvent) {
+			events <- event
+		})
+
+		header := WebhookEventHeader{EventType: "channel.reward.redeemed", EventVersion: "2"}
+
+		err := handler.handleEvent(context.Background(), header, []byte(`{"reward": "hydrate"}`))
+		assert.NoError(t, err)
+
+		assert.Equal(t, mockCustomEvent{Reward: "hydrate"}, receive(t, events))
+
+		header.EventVersion = "1"
+
+		err = handler.handleEvent(context.Background(), header, []byte(`{"reward": "hydrate"}`))
+		assert.ErrorIs(t, err, ErrUnsupportedEventVersion)
+	})
+
+	t.Run("Event without callback", func(t *testing.T) {
+		handler := NewWebhookEventsHandler()
+
+		header := WebhookEventHeader{EventType: EventTypeChannelFollow, EventVersion: "1"}
+
+		assert.NoError(t, handler.handleEvent(context.Background(), header, []byte(`{}`)))
+		assert.ErrorContains(t, handler.handleEvent(context.Background(), header, []byte(`{`)), "unmarshal event body")
+	})
+
+	t.Run("Unexpected events", func(t *testing.T) {
+		handler := NewWebhookEventsHandler(WithUnknownEventPolicy(UnknownEventReject))
+
+		for header, expectedErr := range map[WebhookEventHeader]error{
+			{EventType: "unknown.event"}:                                     ErrUnexpectedEventType,
+			{EventType: "unknown.event", EventVersion: "v1"}:                 ErrUnexpectedEventType,
+			{EventType: EventTypeChatMessage, EventVersion: "v1"}:            ErrUnsupportedEventVersion,
+			{EventType: EventTypeChatMessage, EventVersion: "2"}:             ErrUnsupportedEventVersion,
+			{EventType: EventTypeLivestreamStatusUpdated, EventVersion: "0"}: ErrUnsupportedEventVersion,
+		} {
+			err := handler.handleEvent(context.Background(), header, []byte(`{}`))
+			assert.ErrorIs(t, err, expectedErr, header)
+		}
+	})
+}
+
+func TestWebhookEventsHandler_EventVersions(t *testing.T) {
+	t.Parallel()
+
+	type eventChatMessageV2 struct {
+		ID string `json:"id"`
+	}
+
+	t.Run("Versioned payloads", func(t *testing.T) {
+		var (
+			handler = NewWebhookEventsHandler()
+			v1      = make(chan EventChatMessageV1, 1)
+			v2      = make(chan eventChatMessageV2, 1)
+		)
+
+		On(handler, EventTypeChatMessage, 1, func(_ WebhookEventHeader, event EventChatMessageV1) {
+			v1 <- event
+		})
+		On(handler, EventTypeChatMessage, 2, func(_ WebhookEventHeader, event eventChatMessageV2) {
+			v2 <- event
+		})
+
+		body := []byte(`{"message_id": "v1-id", "id": "v2-id"}`)
+
+		for _, version := range []string{"", "2"} {
+			header := WebhookEventHeader{EventType: EventTypeChatMessage, EventVersion: version}
+			assert.NoError(t, handler.handleEvent(context.Background(), header, body))
+		}
+
+		assert.Equal(t, "v1-id", receive(t, v1).MessageID)
+		assert.Equal(t, "v2-id", receive(t, v2).ID)
+	})
+
+	t.Run("Fallback for unsupported version", func(t *testing.T) {
+		var fallbackHeader WebhookEventHeader
+
+		handler := NewWebhookEventsHandler(
+			WithEventVersionFallback(func(_ context.Context, header WebhookEventHeader, _ []byte) error {
+				fallbackHeader = header
+				return nil
+			}),
+		)
+
+		header := WebhookEventHeader{EventType: EventTypeChannelFollow, EventVersion: "3"}
+
+		assert.NoError(t, handler.handleEvent(context.Background(), header, []byte(`{}`)))
+		assert.Equal(t, header, fallbackHeader)
+
+		// Unknown event types are not passed to the fallback.
+		fallbackHeader = WebhookEventHeader{}
+		header = WebhookEventHeader{EventType: "unknown.event", EventVersion: "3"}
+
+		assert.NoError(t, handler.handleEvent(context.Background(), header, []byte(`{}`)))
+		assert.Equal(t, WebhookEventHeader{}, fallbackHeader)
+	})
+}
+
+func TestWebhookEventsHandler_UnknownEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		header = WebhookEventHeader{EventType: "channel.reward.redeemed", EventVersion: "1"}
+		body   = []byte(`{"reward": "hydrate"}`)
+	)
+
+	t.Run("Acknowledged by default", func(t *testing.T) {
+		handler := NewWebhookEventsHandler()
+
+		assert.NoError(t, handler.handleEvent(context.Background(), header, body))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		handler := NewWebhookEventsHandler(WithUnknownEventPolicy(UnknownEventReject))
+
+		assert.ErrorIs(t, handler.handleEvent(context.Background(), header, body), ErrUnexpectedEventType)
+	})
+
+	t.Run("Forwarded", func(t *testing.T) {
+		type unknownEvent struct {
+			header WebhookEventHeader
+			body   []byte
+		}
+
+		var (
+			handler = NewWebhookEventsHandler(WithUnknownEventPolicy(UnknownEventReject))
+			events  = make(chan unknownEvent, 1)
+		)
+
+		handler.OnUnknownEvent(func(_ context.Context, header WebhookEventHeader, body []byte) error {
+			events <- unknownEvent{header: header, body: body}
+			return nil
+		})
+
+		buffer := bytes.Clone(body)
+
+		assert.NoError(t, handler.handleEvent(context.Background(), header, buffer))
+
+		// Forwarded body must not be affected by reuse of the request's buffer.
+		copy(buffer, "reused")
+
+		event := receive(t, events)
+		assert.Equal(t, header, event.header)
+		assert.Equal(t, body, event.body)
+	})
+}
@@ -0,0 +1,62 @@
+package kicksdk
+
+import (
+	"net/http"
+	"sync"
+)
+
+// AppResolver resolves name of the application that webhook request is sent to.
+type AppResolver func(*http.Request) string
+
+// WebhookAppsRouter routes webhook requests of multiple Kick applications to their own handlers. Since
+// every application has its own webhook URL, application is resolved from the request, by default from
+// the "app" path wildcard (e.g. "POST /webhooks/kick/{app}") or the "app" query parameter.
+type WebhookAppsRouter struct {
+	resolve AppResolver
+
+	handlers       map[string]http.Handler
+	handlersLocker sync.RWMutex
+}
+
+func NewWebhookAppsRouter(options ...WebhookAppsRouterOption) *WebhookAppsRouter {
+	router := &WebhookAppsRouter{
+		resolve:  resolveAppFromRequest,
+		handlers: make(map[string]http.Handler),
+	}
+
+	for _, option := range options {
+		option(router)
+	}
+
+	return router
+}
+
+// Handle registers handler (usually WebhookEventsHandler) of webhooks sent to the application with the
+// provided name.
+func (war *WebhookAppsRouter) Handle(app string, handler http.Handler) {
+	war.handlersLocker.Lock()
+	defer war.handlersLocker.Unlock()
+
+	war.handlers[app] = handler
+}
+
+func (war *WebhookAppsRouter) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	war.handlersLocker.RLock()
+	handler, exist := war.handlers[war.resolve(request)]
+	war.handlersLocker.RUnlock()
+
+	if !exist {
+		http.Error(w, "Unknown application", http.StatusNotFound)
+		return
+	}
+
+	handler.ServeHTTP(w, request)
+}
+
+func resolveAppFromRequest(request *http.Request) string {
+	if app := request.PathValue("app"); len(app) != 0 {
+		return app
+	}
+
+	return request.URL.Query().Get("app")
+}
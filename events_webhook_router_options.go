@@ -0,0 +1,10 @@
+package kicksdk
+
+type WebhookAppsRouterOption func(*WebhookAppsRouter)
+
+// WithAppResolver sets function that resolves application name from the webhook request.
+func WithAppResolver(resolve AppResolver) WebhookAppsRouterOption {
+	return func(router *WebhookAppsRouter) {
+		router.resolve = resolve
+	}
+}
@@ -0,0 +1,66 @@
+package kicksdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookAppsRouter(t *testing.T) {
+	t.Parallel()
+
+	newAppHandler := func(app string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(app))
+		})
+	}
+
+	t.Run("Route by path wildcard", func(t *testing.T) {
+		router := NewWebhookAppsRouter()
+		router.Handle("bot", newAppHandler("bot"))
+		router.Handle("dashboard", newAppHandler("dashboard"))
+
+		mux := http.NewServeMux()
+		mux.Handle("POST /webhooks/{app}", router)
+
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhooks/dashboard", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "dashboard", recorder.Body.String())
+	})
+
+	t.Run("Route by query parameter", func(t *testing.T) {
+		router := NewWebhookAppsRouter()
+		router.Handle("bot", newAppHandler("bot"))
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/webhooks?app=bot", nil))
+
+		assert.Equal(t, "bot", recorder.Body.String())
+	})
+
+	t.Run("Route with custom resolver", func(t *testing.T) {
+		router := NewWebhookAppsRouter(WithAppResolver(func(r *http.Request) string {
+			return r.Header.Get("X-App")
+		}))
+		router.Handle("bot", newAppHandler("bot"))
+
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("X-App", "bot")
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "bot", recorder.Body.String())
+	})
+
+	t.Run("Unknown application", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		NewWebhookAppsRouter().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
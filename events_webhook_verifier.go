@@ -0,0 +1,70 @@
+package kicksdk
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/glichtv/kick-sdk/internal/publickey"
+)
+
+var ErrNoPublicKey = errors.New("public key is not available")
+
+// WebhookVerifier verifies webhook events signatures with the public key that is parsed only once,
+// unlike VerifyWebhookEvent which parses the key on every call.
+type WebhookVerifier struct {
+	publicKey *rsa.PublicKey
+}
+
+// NewWebhookVerifier parses the PEM encoded public key and creates WebhookVerifier with it.
+func NewWebhookVerifier(publicKey string) (*WebhookVerifier, error) {
+	rsaPublicKey, err := publickey.Parse([]byte(publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	return NewWebhookVerifierFromKey(&rsaPublicKey), nil
+}
+
+// NewWebhookVerifierFromKey creates WebhookVerifier with already parsed public key.
+func NewWebhookVerifierFromKey(publicKey *rsa.PublicKey) *WebhookVerifier {
+	return &WebhookVerifier{publicKey: publicKey}
+}
+
+// Verify verifies webhook event signature to ensure that event with provided header and body was actually
+// sent from the Kick's server.
+//
+// Reference: https://docs.kick.com/events/webhook-security#webhook-sender-validation
+func (v *WebhookVerifier) Verify(header WebhookEventHeader, body []byte) error {
+	if v == nil {
+		return ErrNoPublicKey
+	}
+
+	return verifyWebhookEvent(header, v.publicKey, body)
+}
+
+// verifyWebhookEvent verifies webhook event signature with already parsed public key. Signed payload is
+// hashed without concatenating it into a single buffer.
+func verifyWebhookEvent(header WebhookEventHeader, publicKey *rsa.PublicKey, body []byte) error {
+	if publicKey == nil {
+		return ErrNoPublicKey
+	}
+
+	hash := sha256.New()
+
+	_, _ = io.WriteString(hash, header.MessageID)
+	_, _ = io.WriteString(hash, ".")
+	_, _ = io.WriteString(hash, header.MessageTimestamp)
+	_, _ = io.WriteString(hash, ".")
+	_, _ = hash.Write(body)
+
+	var digest [sha256.Size]byte
+
+	if err := publickey.VerifyDigestSignature(publicKey, hash.Sum(digest[:0]), []byte(header.Signature)); err != nil {
+		return fmt.Errorf("verify event signature: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package kicksdk
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mockVerifiedEventBody   = []byte(`{"message_id": "message-id", "content": "Hello, world!"}`)
+	mockVerifiedEventHeader = WebhookEventHeader{
+		MessageID:        "message-id",
+		MessageTimestamp: "2025-01-01T00:00:00Z",
+		EventType:        EventTypeChatMessage,
+		EventVersion:     "1",
+	}
+)
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	key := newMockSigningKey(t)
+
+	header := mockVerifiedEventHeader
+	header.Signature = key.sign(t, header, mockVerifiedEventBody)
+
+	verifier, err := NewWebhookVerifier(key.publicPEM)
+	assert.NoError(t, err)
+
+	t.Run("Valid signature", func(t *testing.T) {
+		assert.NoError(t, verifier.Verify(header, mockVerifiedEventBody))
+		assert.NoError(t, VerifyWebhookEvent(header, key.publicPEM, mockVerifiedEventBody))
+	})
+
+	t.Run("Modified body", func(t *testing.T) {
+		err := verifier.Verify(header, []byte(`{"message_id": "message-id"}`))
+		assert.ErrorContains(t, err, "verify event signature")
+	})
+
+	t.Run("Another key", func(t *testing.T) {
+		another, err := NewWebhookVerifier(newMockSigningKey(t).publicPEM)
+		assert.NoError(t, err)
+
+		assert.Error(t, another.Verify(header, mockVerifiedEventBody))
+	})
+
+	t.Run("Invalid public key", func(t *testing.T) {
+		_, err := NewWebhookVerifier("invalid-public-key")
+		assert.ErrorContains(t, err, "parse public key")
+	})
+
+	t.Run("Nil verifier", func(t *testing.T) {
+		var nilVerifier *WebhookVerifier
+
+		assert.ErrorIs(t, nilVerifier.Verify(header, mockVerifiedEventBody), ErrNoPublicKey)
+	})
+}
+
+func TestWebhookEventsHandler_MaxBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	handler := NewWebhookEventsHandler(
+		WithDisabledEventsVerification(),
+		WithMaxBodyBytes(8),
+		WithEventsHandler(func(context.Context, WebhookEventHeader, []byte) error { return nil }),
+	)
+
+	for body, expectedCode := range map[string]int{
+		"12345678":  http.StatusOK,
+		"123456789": http.StatusRequestEntityTooLarge,
+	} {
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, newMockEventRequest(strings.NewReader(body), mockEventHeader))
+
+		assert.Equal(t, expectedCode, recorder.Code, body)
+	}
+}
+
+func BenchmarkVerifyWebhookEvent(b *testing.B) {
+	key := newMockSigningKey(b)
+
+	header := mockVerifiedEventHeader
+	header.Signature = key.sign(b, header, mockVerifiedEventBody)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if err := VerifyWebhookEvent(header, key.publicPEM, mockVerifiedEventBody); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWebhookVerifier_Verify(b *testing.B) {
+	key := newMockSigningKey(b)
+
+	header := mockVerifiedEventHeader
+	header.Signature = key.sign(b, header, mockVerifiedEventBody)
+
+	verifier, err := NewWebhookVerifier(key.publicPEM)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if err = verifier.Verify(header, mockVerifiedEventBody); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWebhookEventsHandler_ServeHTTP(b *testing.B) {
+	key := newMockSigningKey(b)
+
+	header := mockVerifiedEventHeader
+	header.Signature = key.sign(b, header, mockVerifiedEventBody)
+
+	handler := NewWebhookEventsHandler(
+		WithPublicKey(key.publicPEM),
+		WithEventsHandler(func(context.Context, WebhookEventHeader, []byte) error { return nil }),
+	)
+
+	var (
+		recorder = httptest.NewRecorder()
+		reader   = bytes.NewReader(mockVerifiedEventBody)
+		request  = newMockEventRequest(reader, header)
+	)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		reader.Reset(mockVerifiedEventBody)
+		recorder.Code = http.StatusOK
+
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != http.StatusOK {
+			b.Fatalf("unexpected status code %d", recorder.Code)
+		}
+	}
+}
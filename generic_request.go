@@ -25,6 +25,7 @@ type (
 		Resource  Resource
 		Method    string
 		AuthType  AuthorizationType
+		Scopes    []OAuthScope
 		URLValues urloptional.Values
 		Body      any
 	}
@@ -44,6 +45,10 @@ func (r Request[Output]) Execute() (Response[Output], error) {
 		return Response[Output]{}, fmt.Errorf("build request: %w", err)
 	}
 
+	if err = r.checkScopes(); err != nil {
+		return Response[Output]{}, fmt.Errorf("check scopes: %w", err)
+	}
+
 	response, err := r.client.httpClient.Do(request)
 	if err != nil {
 		return Response[Output]{}, fmt.Errorf("do request: %w", err)
@@ -55,6 +60,24 @@ func (r Request[Output]) Execute() (Response[Output], error) {
 	return parseResponse[Output](response, r.options.Resource.Type)
 }
 
+// checkScopes ensures that the user access token has all the scopes required by the requested
+// resource. Check is performed only if it's enabled for the client and token's scopes are known.
+func (r Request[Output]) checkScopes() error {
+	if !r.client.enforceScopes || r.options.AuthType != AuthTypeUserToken {
+		return nil
+	}
+
+	if r.client.tokens.UserScopes == nil || len(r.options.Scopes) == 0 {
+		return nil
+	}
+
+	if missing := r.client.tokens.UserScopes.Missing(r.options.Scopes...); len(missing) != 0 {
+		return MissingScopesError{Scopes: missing}
+	}
+
+	return nil
+}
+
 // Build builds an HTTP request based on the original RequestOptions.
 func (r Request[Output]) Build() (*http.Request, error) {
 	resourceURL := r.options.Resource.URL()
@@ -82,10 +105,7 @@ func (r Request[Output]) Build() (*http.Request, error) {
 }
 
 func parseResponse[Output any](response *http.Response, resource ResourceType) (Response[Output], error) {
-	metadata := ResponseMetadata{
-		StatusCode: response.StatusCode,
-		Header:     response.Header,
-	}
+	metadata := newResponseMetadata(response)
 
 	if response.StatusCode == http.StatusNoContent {
 		return Response[Output]{ResponseMetadata: metadata}, nil
@@ -582,3 +582,63 @@ func assertDefaultResponseMeta(t *testing.T, result, expected ResponseMetadata)
 	assert.Equal(t, expected.StatusCode, result.StatusCode)
 	assert.Equal(t, expected.Header, result.Header)
 }
+
+func TestRequest_CheckScopes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requested bool
+		client    = newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data": {"value": "test"}}`))
+		})
+	)
+
+	newRequest := func(client *Client) Request[mockTestOutput] {
+		return NewRequest[mockTestOutput](context.Background(), client, RequestOptions{
+			Resource: client.NewResource(ResourceTypeAPI, ""),
+			Method:   http.MethodPost,
+			AuthType: AuthTypeUserToken,
+			Scopes:   []OAuthScope{ScopeChatWrite, ScopeChannelWrite},
+		})
+	}
+
+	t.Run("Enforcement is disabled", func(t *testing.T) {
+		scopedClient := client.WithAccessTokens(AccessTokens{
+			UserAccessToken: "token",
+			UserScopes:      NewScopeSet(ScopeUserRead),
+		})
+
+		err := newRequest(scopedClient).checkScopes()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Token scopes are unknown", func(t *testing.T) {
+		WithScopesEnforcement()(client)
+
+		scopedClient := client.WithAccessTokens(AccessTokens{UserAccessToken: "token"})
+
+		err := newRequest(scopedClient).checkScopes()
+		assert.NoError(t, err)
+	})
+
+	t.Run("Token is missing scopes", func(t *testing.T) {
+		WithScopesEnforcement()(client)
+
+		scopedClient := client.WithAccessTokens(AccessTokens{
+			UserAccessToken: "token",
+			UserScopes:      NewScopeSet(ScopeUserRead, ScopeChatWrite),
+		})
+
+		_, err := newRequest(scopedClient).Execute()
+		assert.ErrorIs(t, err, ErrMissingScope)
+		assert.False(t, requested)
+
+		var missingErr MissingScopesError
+
+		assert.True(t, errors.As(err, &missingErr))
+		assert.Equal(t, []OAuthScope{ScopeChannelWrite}, missingErr.Scopes)
+	})
+}
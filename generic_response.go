@@ -1,6 +1,11 @@
 package kicksdk
 
-import "net/http"
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type (
 	apiResponse[Payload any] struct {
@@ -30,9 +35,121 @@ type ResponseMetadata struct {
 	StatusCode int
 	Header     http.Header
 
+	// RateLimit is a rate limit state reported by Kick along with the response.
+	RateLimit RateLimit
+	// RetryAfter is a duration to wait before retrying the request, parsed from the Retry-After header.
+	// It is zero if the header is not present.
+	RetryAfter time.Duration
+	// RequestID is an ID of the request (or trace) assigned by Kick that can be used to
+	// identify the request when contacting support.
+	RequestID string
+	// Date is a server's time when the response was sent, parsed from the Date header. It is zero if
+	// the header is not present.
+	Date time.Time
+
 	// KickMessage is a message that Kick sends along with the optional data in response to the API requests.
 	// In case of an unsuccessful Request it will contain error message as to why the Request failed.
 	KickMessage          string
 	KickError            string
 	KickErrorDescription string
 }
+
+// RateLimit is a rate limit state of the requested resource. Each field is zero if the corresponding
+// header is not present in the response.
+type RateLimit struct {
+	// Limit is a maximum number of requests allowed within the window.
+	Limit int
+	// Remaining is a number of requests remaining within the current window.
+	Remaining int
+	// Reset is a time when the current window resets.
+	Reset time.Time
+}
+
+var (
+	rateLimitLimitHeaders     = []string{"X-RateLimit-Limit", "RateLimit-Limit"}
+	rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+	rateLimitResetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+	requestIDHeaders          = []string{"X-Request-Id", "X-Trace-Id", "Request-Id"}
+)
+
+// unixResetThreshold separates reset values that are unix timestamps from the ones that are
+// amount of seconds until reset.
+const unixResetThreshold = 1_000_000_000
+
+func newResponseMetadata(response *http.Response) ResponseMetadata {
+	metadata := ResponseMetadata{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+	}
+
+	if len(response.Header) == 0 {
+		return metadata
+	}
+
+	// Relative reset is calculated against the local time, so it can be compared with time.Now()
+	// regardless of the clock skew between the client and the server.
+	receivedAt := time.Now()
+	metadata.RateLimit = parseRateLimit(response.Header, receivedAt)
+
+	// Retry-After date is set by the server, so it's compared with the server's time when it's available.
+	serverNow := receivedAt
+
+	if date, err := http.ParseTime(response.Header.Get("Date")); err == nil {
+		metadata.Date = date
+		serverNow = date
+	}
+
+	metadata.RetryAfter = parseRetryAfter(response.Header.Get("Retry-After"), serverNow)
+	metadata.RequestID = firstHeader(response.Header, requestIDHeaders)
+
+	return metadata
+}
+
+func parseRateLimit(header http.Header, now time.Time) RateLimit {
+	var rateLimit RateLimit
+
+	if limit, err := strconv.Atoi(firstHeader(header, rateLimitLimitHeaders)); err == nil {
+		rateLimit.Limit = limit
+	}
+
+	if remaining, err := strconv.Atoi(firstHeader(header, rateLimitRemainingHeaders)); err == nil {
+		rateLimit.Remaining = remaining
+	}
+
+	if reset, err := strconv.ParseInt(firstHeader(header, rateLimitResetHeaders), 10, 64); err == nil {
+		if reset >= unixResetThreshold {
+			rateLimit.Reset = time.Unix(reset, 0)
+		} else {
+			rateLimit.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	return rateLimit
+}
+
+// parseRetryAfter parses Retry-After header value which is either an amount of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+
+	return 0
+}
+
+func firstHeader(header http.Header, keys []string) string {
+	for _, key := range keys {
+		if value := strings.TrimSpace(header.Get(key)); len(value) != 0 {
+			return value
+		}
+	}
+
+	return ""
+}
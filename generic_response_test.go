@@ -0,0 +1,83 @@
+package kicksdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResponseMetadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Response without headers", func(t *testing.T) {
+		metadata := newResponseMetadata(&http.Response{StatusCode: http.StatusOK})
+
+		assert.Equal(t, ResponseMetadata{StatusCode: http.StatusOK}, metadata)
+	})
+
+	t.Run("Response with rate limit headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+		header.Set("X-RateLimit-Limit", "100")
+		header.Set("X-RateLimit-Remaining", "42")
+		header.Set("X-RateLimit-Reset", "30")
+		header.Set("Retry-After", "5")
+		header.Set("X-Request-Id", "request-id")
+
+		before := time.Now()
+
+		metadata := newResponseMetadata(&http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+		})
+
+		after := time.Now()
+
+		// Relative reset is based on the local time, not on the server's Date.
+		assert.False(t, metadata.RateLimit.Reset.Before(before.Add(30*time.Second)))
+		assert.False(t, metadata.RateLimit.Reset.After(after.Add(30*time.Second)))
+		assert.True(t, time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC).Equal(metadata.Date))
+		assert.Equal(t, 100, metadata.RateLimit.Limit)
+		assert.Equal(t, 42, metadata.RateLimit.Remaining)
+		assert.Equal(t, 5*time.Second, metadata.RetryAfter)
+		assert.Equal(t, "request-id", metadata.RequestID)
+	})
+
+	t.Run("Response with unix reset and trace ID", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("RateLimit-Reset", "1700000000")
+		header.Set("X-Trace-Id", "trace-id")
+
+		metadata := newResponseMetadata(&http.Response{Header: header})
+
+		assert.True(t, time.Unix(1700000000, 0).Equal(metadata.RateLimit.Reset))
+		assert.Equal(t, "trace-id", metadata.RequestID)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+	}{
+		{name: "Empty value", value: "", expected: 0},
+		{name: "Seconds", value: "120", expected: 2 * time.Minute},
+		{name: "Negative seconds", value: "-1", expected: 0},
+		{name: "HTTP date", value: "Mon, 02 Jan 2006 15:04:15 GMT", expected: 10 * time.Second},
+		{name: "Date in the past", value: "Mon, 02 Jan 2006 15:00:00 GMT", expected: 0},
+		{name: "Invalid value", value: "soon", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseRetryAfter(test.value, now))
+		})
+	}
+}
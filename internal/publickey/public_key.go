@@ -41,17 +41,33 @@ func Parse(payload []byte) (rsa.PublicKey, error) {
 	return *publicKey, nil
 }
 
+// maxStackSignatureSize is a size of the decoded signature that fits into the stack buffer, which is
+// enough for the keys up to 4096 bits.
+const maxStackSignatureSize = 512
+
 // VerifyEventSignature verifies that the signature is valid for the provided public key and event body.
 func VerifyEventSignature(publicKey *rsa.PublicKey, body []byte, signature []byte) error {
-	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(signature)))
+	hashedBody := sha256.Sum256(body)
+
+	return VerifyDigestSignature(publicKey, hashedBody[:], signature)
+}
+
+// VerifyDigestSignature verifies that the base64 encoded signature is valid for the provided public key
+// and SHA-256 digest of the signed payload.
+func VerifyDigestSignature(publicKey *rsa.PublicKey, digest []byte, signature []byte) error {
+	var (
+		buffer  [maxStackSignatureSize]byte
+		decoded = buffer[:]
+	)
+
+	if size := base64.StdEncoding.DecodedLen(len(signature)); size > len(decoded) {
+		decoded = make([]byte, size)
+	}
 
 	at, err := base64.StdEncoding.Decode(decoded, signature)
 	if err != nil {
 		return fmt.Errorf("decode signature: %w", err)
 	}
 
-	signature = decoded[:at]
-	hashedBody := sha256.Sum256(body)
-
-	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashedBody[:], signature)
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, decoded[:at])
 }
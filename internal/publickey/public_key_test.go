@@ -170,3 +170,31 @@ func TestVerifyEventSignature(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyDigestSignature(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("test"))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+
+	t.Run("Valid signature", func(t *testing.T) {
+		err := VerifyDigestSignature(
+			&privateKey.PublicKey,
+			digest[:],
+			[]byte(base64.StdEncoding.EncodeToString(signature)),
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Signature larger than the stack buffer", func(t *testing.T) {
+		oversized := base64.StdEncoding.EncodeToString(make([]byte, 2*maxStackSignatureSize))
+
+		err := VerifyDigestSignature(&privateKey.PublicKey, digest[:], []byte(oversized))
+		assert.ErrorIs(t, err, rsa.ErrVerification)
+	})
+}
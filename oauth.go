@@ -1,5 +1,14 @@
 package kicksdk
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
 // OAuthScope is a scope that enable an app to request a level of access to Kick and define
 // the specific actions an application can perform.
 //
@@ -15,6 +24,210 @@ const (
 	ScopeEventsSubscribe OAuthScope = "events:subscribe"
 )
 
+// ErrMissingScope is returned (wrapped into the MissingScopesError) when the access token lacks
+// scopes that are required by the endpoint.
+var ErrMissingScope = errors.New("access token is missing required scopes")
+
+// MissingScopesError describes exactly which scopes must be requested from the user in order to
+// call the endpoint.
+type MissingScopesError struct {
+	Scopes []OAuthScope
+}
+
+func (e MissingScopesError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingScope, joinScopes(e.Scopes, ", "))
+}
+
+func (e MissingScopesError) Unwrap() error {
+	return ErrMissingScope
+}
+
+// Errors that OAuthError matches depending on its code.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+var (
+	ErrInvalidRequest         = errors.New("invalid request")
+	ErrInvalidClient          = errors.New("invalid client")
+	ErrInvalidGrant           = errors.New("invalid grant")
+	ErrUnauthorizedClient     = errors.New("unauthorized client")
+	ErrUnsupportedGrantType   = errors.New("unsupported grant type")
+	ErrInvalidScope           = errors.New("invalid scope")
+	ErrAccessDenied           = errors.New("access denied")
+	ErrTemporarilyUnavailable = errors.New("temporarily unavailable")
+	ErrServerError            = errors.New("authorization server error")
+)
+
+var oauthErrorCodes = map[string]error{
+	"invalid_request":         ErrInvalidRequest,
+	"invalid_client":          ErrInvalidClient,
+	"invalid_grant":           ErrInvalidGrant,
+	"unauthorized_client":     ErrUnauthorizedClient,
+	"unsupported_grant_type":  ErrUnsupportedGrantType,
+	"invalid_scope":           ErrInvalidScope,
+	"access_denied":           ErrAccessDenied,
+	"temporarily_unavailable": ErrTemporarilyUnavailable,
+	"server_error":            ErrServerError,
+}
+
+// OAuthError is an error returned by the Kick's authorization server, either to the redirect URI
+// or in response to the token requests. It matches one of the Err* errors depending on its code,
+// e.g. errors.Is(err, ErrInvalidGrant) reports whether refresh token is revoked.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2.1
+type OAuthError struct {
+	// StatusCode is an HTTP status code of the response, zero if error is returned to the redirect URI.
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e OAuthError) Is(target error) bool {
+	codeErr, known := oauthErrorCodes[e.Code]
+	return known && codeErr == target
+}
+
+// Temporary reports whether request failed due to the transient failure of the authorization server
+// and might succeed if retried.
+func (e OAuthError) Temporary() bool {
+	return e.Code == "temporarily_unavailable" || e.Code == "server_error" ||
+		e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+func (e OAuthError) Error() string {
+	if len(e.Code) == 0 {
+		return fmt.Sprintf("oauth error: unexpected status code %d", e.StatusCode)
+	}
+
+	if len(e.Description) == 0 {
+		return fmt.Sprintf("oauth error: %s", e.Code)
+	}
+
+	return fmt.Sprintf("oauth error: %s: %s", e.Code, e.Description)
+}
+
+// ScopeSet is a set of the OAuth scopes. Nil set is used to represent unknown scopes.
+type ScopeSet map[OAuthScope]struct{}
+
+func NewScopeSet(scopes ...OAuthScope) ScopeSet {
+	set := make(ScopeSet, len(scopes))
+
+	for _, scope := range scopes {
+		set[scope] = struct{}{}
+	}
+
+	return set
+}
+
+// ParseScopes parses space-separated scopes as they are returned by Kick in the AccessToken
+// and TokenInfo.
+func ParseScopes(scope string) ScopeSet {
+	return NewScopeSet(ParseScopesList(scope)...)
+}
+
+// ParseScopesList parses space-separated scopes into the list keeping their original order.
+func ParseScopesList(scope string) []OAuthScope {
+	fields := strings.Fields(scope)
+	scopes := make([]OAuthScope, len(fields))
+
+	for index, field := range fields {
+		scopes[index] = OAuthScope(field)
+	}
+
+	return scopes
+}
+
+// Has returns true if all the provided scopes are in the set.
+func (s ScopeSet) Has(scopes ...OAuthScope) bool {
+	for _, scope := range scopes {
+		if _, exist := s[scope]; !exist {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Missing returns scopes that are not in the set, keeping the order of the required scopes.
+func (s ScopeSet) Missing(required ...OAuthScope) []OAuthScope {
+	var missing []OAuthScope
+
+	for _, scope := range required {
+		if _, exist := s[scope]; !exist {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
+
+// Union returns a new set with scopes of both sets.
+func (s ScopeSet) Union(other ScopeSet) ScopeSet {
+	union := make(ScopeSet, len(s)+len(other))
+
+	for scope := range s {
+		union[scope] = struct{}{}
+	}
+
+	for scope := range other {
+		union[scope] = struct{}{}
+	}
+
+	return union
+}
+
+// List returns scopes of the set sorted alphabetically.
+func (s ScopeSet) List() []OAuthScope {
+	scopes := make([]OAuthScope, 0, len(s))
+
+	for scope := range s {
+		scopes = append(scopes, scope)
+	}
+
+	slices.Sort(scopes)
+
+	return scopes
+}
+
+// String returns space-separated scopes of the set in the same format as Kick does.
+func (s ScopeSet) String() string {
+	return joinScopes(s.List(), " ")
+}
+
+func (s ScopeSet) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(s.List())
+}
+
+func (s *ScopeSet) UnmarshalJSON(data []byte) error {
+	var scopes []OAuthScope
+
+	if err := json.Unmarshal(data, &scopes); err != nil {
+		return err
+	}
+
+	if scopes == nil {
+		*s = nil
+		return nil
+	}
+
+	*s = NewScopeSet(scopes...)
+
+	return nil
+}
+
+func joinScopes(scopes []OAuthScope, separator string) string {
+	values := make([]string, len(scopes))
+
+	for index, scope := range scopes {
+		values[index] = string(scope)
+	}
+
+	return strings.Join(values, separator)
+}
+
 // AuthorizationType is a type of authorization (token) that will be used to authorize
 // requests to the Kick's APIs.
 type AuthorizationType int
@@ -26,6 +239,10 @@ const (
 type (
 	AccessTokens struct {
 		UserAccessToken string
+		// UserScopes are scopes granted to the UserAccessToken. They are used to check endpoint
+		// requirements before sending requests when scopes enforcement is enabled. Scopes are
+		// considered unknown when nil.
+		UserScopes ScopeSet
 	}
 
 	Credentials struct {
@@ -0,0 +1,242 @@
+package kicksdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/glichtv/kick-sdk/optional"
+)
+
+const (
+	defaultOAuthCookieName = "kick_oauth_session"
+	defaultOAuthCookiePath = "/"
+	defaultOAuthCookieTTL  = 10 * time.Minute
+)
+
+var (
+	ErrNoAuthorizationSession = errors.New("authorization session cookie is missing or invalid")
+	ErrNoAuthorizationCode    = errors.New("authorization code is not passed but required")
+	ErrNoUserProfile          = errors.New("profile of the authorized user is not returned")
+)
+
+type (
+	// OAuthSuccessCallback is called once user is authorized, with its access token and profile.
+	OAuthSuccessCallback func(http.ResponseWriter, *http.Request, AccessToken, User)
+	// OAuthErrorCallback is called when authorization fails on any step.
+	OAuthErrorCallback func(http.ResponseWriter, *http.Request, error)
+
+	// OAuthHandler provides HTTP handlers for the authorization code grant flow with PKCE: login handler
+	// redirects user to the Kick's authorization page, and callback handler exchanges the code returned to the
+	// redirect URI. Authorization session is kept in the HTTP-only cookie between the two.
+	OAuthHandler struct {
+		client *Client
+		scopes []OAuthScope
+
+		cookieName   string
+		cookiePath   string
+		cookieTTL    time.Duration
+		secureCookie bool
+
+		onSuccess OAuthSuccessCallback
+		onError   OAuthErrorCallback
+	}
+)
+
+// NewOAuthHandler creates OAuthHandler that uses credentials of the provided client. The ScopeUserRead is
+// always requested, since it's required to retrieve profile of the authorized user.
+func NewOAuthHandler(client *Client, onSuccess OAuthSuccessCallback, options ...OAuthHandlerOption) *OAuthHandler {
+	handler := &OAuthHandler{
+		client:       client,
+		scopes:       []OAuthScope{ScopeUserRead},
+		cookieName:   defaultOAuthCookieName,
+		cookiePath:   defaultOAuthCookiePath,
+		cookieTTL:    defaultOAuthCookieTTL,
+		secureCookie: true,
+		onSuccess:    onSuccess,
+		onError:      defaultOAuthErrorCallback,
+	}
+
+	for _, option := range options {
+		option(handler)
+	}
+
+	if !slices.Contains(handler.scopes, ScopeUserRead) {
+		handler.scopes = append(handler.scopes, ScopeUserRead)
+	}
+
+	return handler
+}
+
+// LoginHandler returns handler that starts authorization and redirects user to the authorization page.
+func (oh *OAuthHandler) LoginHandler() http.Handler {
+	return http.HandlerFunc(oh.serveLogin)
+}
+
+// CallbackHandler returns handler that must be served on the redirect URI of the application.
+func (oh *OAuthHandler) CallbackHandler() http.Handler {
+	return http.HandlerFunc(oh.serveCallback)
+}
+
+func (oh *OAuthHandler) serveLogin(w http.ResponseWriter, request *http.Request) {
+	session, err := oh.client.OAuth().StartAuthorization(StartAuthorizationInput{
+		Scopes: oh.scopes,
+	})
+	if err != nil {
+		oh.onError(w, request, fmt.Errorf("start authorization: %w", err))
+		return
+	}
+
+	cookieValue, err := encodeAuthorizationSession(session)
+	if err != nil {
+		oh.onError(w, request, fmt.Errorf("encode authorization session: %w", err))
+		return
+	}
+
+	http.SetCookie(w, oh.newCookie(cookieValue, int(oh.cookieTTL.Seconds())))
+	http.Redirect(w, request, session.URL, http.StatusFound)
+}
+
+func (oh *OAuthHandler) serveCallback(w http.ResponseWriter, request *http.Request) {
+	// Session is single-use, so it's removed regardless of the authorization result.
+	http.SetCookie(w, oh.newCookie("", -1))
+
+	query := request.URL.Query()
+
+	if code := query.Get("error"); len(code) != 0 {
+		oh.onError(w, request, OAuthError{
+			Code:        code,
+			Description: query.Get("error_description"),
+		})
+
+		return
+	}
+
+	code := query.Get("code")
+	if len(code) == 0 {
+		oh.onError(w, request, ErrNoAuthorizationCode)
+		return
+	}
+
+	cookie, err := request.Cookie(oh.cookieName)
+	if err != nil {
+		oh.onError(w, request, ErrNoAuthorizationSession)
+		return
+	}
+
+	session, err := decodeAuthorizationSession(cookie.Value)
+	if err != nil {
+		oh.onError(w, request, ErrNoAuthorizationSession)
+		return
+	}
+
+	token, user, err := oh.authorize(request, session, code, query.Get("state"))
+	if err != nil {
+		oh.onError(w, request, err)
+		return
+	}
+
+	oh.onSuccess(w, request, token, user)
+}
+
+func (oh *OAuthHandler) authorize(
+	request *http.Request,
+	session AuthorizationSession,
+	code, state string,
+) (AccessToken, User, error) {
+	tokenResponse, err := oh.client.OAuth().ExchangeCode(request.Context(), ExchangeCodeInput{
+		Code:    code,
+		Session: optional.From(session),
+		State:   state,
+	})
+	if err != nil {
+		return AccessToken{}, User{}, fmt.Errorf("exchange code: %w", err)
+	}
+
+	token := tokenResponse.Payload
+
+	// Users are retrieved without IDs to get the profile of the token's owner.
+	usersResponse, err := oh.client.
+		WithAccessTokens(AccessTokens{
+			UserAccessToken: token.AccessToken,
+			UserScopes:      token.Scopes(),
+		}).
+		Users().
+		GetByIDs(request.Context(), GetUsersByIDsInput{})
+	if err != nil {
+		return AccessToken{}, User{}, fmt.Errorf("get user: %w", err)
+	}
+
+	if usersResponse.ResponseMetadata.StatusCode != http.StatusOK || len(usersResponse.Payload) == 0 {
+		return AccessToken{}, User{}, ErrNoUserProfile
+	}
+
+	return token, usersResponse.Payload[0], nil
+}
+
+func (oh *OAuthHandler) newCookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oh.cookieName,
+		Value:    value,
+		Path:     oh.cookiePath,
+		MaxAge:   maxAge,
+		Secure:   oh.secureCookie,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func encodeAuthorizationSession(session AuthorizationSession) (string, error) {
+	// Authorization URL is not needed after redirect and only bloats the cookie.
+	session.URL = ""
+
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		return "", fmt.Errorf("marshal session: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(sessionBytes), nil
+}
+
+func decodeAuthorizationSession(value string) (AuthorizationSession, error) {
+	sessionBytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return AuthorizationSession{}, fmt.Errorf("decode session: %w", err)
+	}
+
+	var session AuthorizationSession
+
+	if err = json.Unmarshal(sessionBytes, &session); err != nil {
+		return AuthorizationSession{}, fmt.Errorf("unmarshal session: %w", err)
+	}
+
+	return session, nil
+}
+
+// defaultOAuthErrorCallback responds with 400 if authorization failed due to the user's request, and with 502
+// or 503 if the authorization server failed, so its failures aren't mistaken for the bad user's input.
+func defaultOAuthErrorCallback(w http.ResponseWriter, _ *http.Request, err error) {
+	var oauthErr OAuthError
+
+	switch {
+	case errors.As(err, &oauthErr) && oauthErr.Temporary():
+		status := http.StatusBadGateway
+		if errors.Is(oauthErr, ErrTemporarilyUnavailable) || oauthErr.StatusCode == http.StatusServiceUnavailable ||
+			oauthErr.StatusCode == http.StatusTooManyRequests {
+			status = http.StatusServiceUnavailable
+		}
+
+		http.Error(w, "Authorization server is unavailable", status)
+	case errors.As(err, &oauthErr),
+		errors.Is(err, ErrNoAuthorizationCode),
+		errors.Is(err, ErrNoAuthorizationSession),
+		errors.Is(err, ErrStateMismatch):
+		http.Error(w, "Authorization failed", http.StatusBadRequest)
+	default:
+		http.Error(w, "Cannot complete authorization", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,50 @@
+package kicksdk
+
+import (
+	"slices"
+	"time"
+)
+
+type OAuthHandlerOption func(*OAuthHandler)
+
+func WithOAuthScopes(scopes ...OAuthScope) OAuthHandlerOption {
+	return func(handler *OAuthHandler) {
+		// Scopes are copied, since handler appends required scopes to them.
+		handler.scopes = slices.Clone(scopes)
+	}
+}
+
+func WithOAuthErrorCallback(onError OAuthErrorCallback) OAuthHandlerOption {
+	return func(handler *OAuthHandler) {
+		handler.onError = onError
+	}
+}
+
+// WithOAuthCookie sets name and path of the cookie that keeps authorization session between
+// login and callback.
+func WithOAuthCookie(name, path string) OAuthHandlerOption {
+	return func(handler *OAuthHandler) {
+		if len(name) != 0 {
+			handler.cookieName = name
+		}
+
+		if len(path) != 0 {
+			handler.cookiePath = path
+		}
+	}
+}
+
+// WithOAuthCookieTTL sets for how long user can complete authorization after login.
+func WithOAuthCookieTTL(ttl time.Duration) OAuthHandlerOption {
+	return func(handler *OAuthHandler) {
+		handler.cookieTTL = ttl
+	}
+}
+
+// WithInsecureOAuthCookie disables Secure attribute of the session cookie, which might be required
+// for the local development over plain HTTP.
+func WithInsecureOAuthCookie() OAuthHandlerOption {
+	return func(handler *OAuthHandler) {
+		handler.secureCookie = false
+	}
+}
@@ -0,0 +1,250 @@
+package kicksdk
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockOAuthServer(t *testing.T) *Client {
+	t.Helper()
+
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/token":
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "code" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(AccessToken{AccessToken: "access-token", Scope: "user:read"})
+		case "/public/v1/users":
+			if r.Header.Get("Authorization") != "Bearer access-token" {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data": [{"id": 42, "name": "user"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	client.credentials = Credentials{
+		ClientID:    "client-id",
+		RedirectURI: "http://localhost/callback",
+	}
+
+	return client
+}
+
+func startMockOAuthLogin(t *testing.T, handler *OAuthHandler) (*http.Cookie, string) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler.LoginHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login", nil))
+
+	assert.Equal(t, http.StatusFound, recorder.Code)
+
+	location, err := url.Parse(recorder.Header().Get("Location"))
+	assert.NoError(t, err)
+
+	cookies := recorder.Result().Cookies()
+	assert.Len(t, cookies, 1)
+
+	return cookies[0], location.Query().Get("state")
+}
+
+func TestOAuthHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Successful authorization", func(t *testing.T) {
+		var (
+			authorizedToken AccessToken
+			authorizedUser  User
+		)
+
+		handler := NewOAuthHandler(
+			newMockOAuthServer(t),
+			func(w http.ResponseWriter, _ *http.Request, token AccessToken, user User) {
+				authorizedToken, authorizedUser = token, user
+				w.WriteHeader(http.StatusOK)
+			},
+			WithOAuthScopes(ScopeChatWrite),
+		)
+
+		cookie, state := startMockOAuthLogin(t, handler)
+
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+state, nil)
+		)
+
+		request.AddCookie(cookie)
+		handler.CallbackHandler().ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "access-token", authorizedToken.AccessToken)
+		assert.Equal(t, 42, authorizedUser.ID)
+		assert.Equal(t, []OAuthScope{ScopeChatWrite, ScopeUserRead}, handler.scopes)
+	})
+
+	t.Run("Callback with mismatching state", func(t *testing.T) {
+		var callbackErr error
+
+		handler := NewOAuthHandler(
+			newMockOAuthServer(t),
+			nil,
+			WithOAuthErrorCallback(func(w http.ResponseWriter, _ *http.Request, err error) {
+				callbackErr = err
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+		)
+
+		cookie, _ := startMockOAuthLogin(t, handler)
+
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/callback?code=code&state=forged", nil)
+		)
+
+		request.AddCookie(cookie)
+		handler.CallbackHandler().ServeHTTP(recorder, request)
+
+		assert.ErrorIs(t, callbackErr, ErrStateMismatch)
+	})
+
+	t.Run("Callback with authorization error", func(t *testing.T) {
+		var (
+			handler  = NewOAuthHandler(newMockOAuthServer(t), nil)
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(
+				http.MethodGet,
+				"/callback?error=access_denied&error_description=denied",
+				nil,
+			)
+		)
+
+		handler.CallbackHandler().ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Callback without session cookie", func(t *testing.T) {
+		var callbackErr error
+
+		handler := NewOAuthHandler(
+			newMockOAuthServer(t),
+			nil,
+			WithOAuthErrorCallback(func(_ http.ResponseWriter, _ *http.Request, err error) {
+				callbackErr = err
+			}),
+		)
+
+		handler.CallbackHandler().ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/callback?code=code&state=state", nil),
+		)
+
+		assert.ErrorIs(t, callbackErr, ErrNoAuthorizationSession)
+	})
+
+	t.Run("Callback with rejected code", func(t *testing.T) {
+		var callbackErr error
+
+		handler := NewOAuthHandler(
+			newMockOAuthServer(t),
+			nil,
+			WithOAuthErrorCallback(func(_ http.ResponseWriter, _ *http.Request, err error) {
+				callbackErr = err
+			}),
+		)
+
+		cookie, state := startMockOAuthLogin(t, handler)
+
+		request := httptest.NewRequest(http.MethodGet, "/callback?code=invalid&state="+state, nil)
+		request.AddCookie(cookie)
+
+		handler.CallbackHandler().ServeHTTP(httptest.NewRecorder(), request)
+
+		var oauthErr OAuthError
+
+		assert.True(t, errors.As(callbackErr, &oauthErr))
+		assert.Equal(t, "invalid_grant", oauthErr.Code)
+	})
+}
+
+func TestDefaultOAuthErrorCallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "Invalid grant",
+			err:      OAuthError{StatusCode: http.StatusBadRequest, Code: "invalid_grant"},
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "Server error",
+			err:      OAuthError{StatusCode: http.StatusInternalServerError, Code: "server_error"},
+			expected: http.StatusBadGateway,
+		},
+		{
+			name:     "Temporarily unavailable",
+			err:      OAuthError{Code: "temporarily_unavailable"},
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "Too many requests",
+			err:      OAuthError{StatusCode: http.StatusTooManyRequests},
+			expected: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "State mismatch",
+			err:      ErrStateMismatch,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "Unknown error",
+			err:      errors.New("unknown"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			defaultOAuthErrorCallback(recorder, httptest.NewRequest(http.MethodGet, "/callback", nil), test.err)
+
+			assert.Equal(t, test.expected, recorder.Code)
+		})
+	}
+}
+
+func TestWithOAuthScopes(t *testing.T) {
+	t.Parallel()
+
+	scopes := make([]OAuthScope, 1, 4)
+	scopes[0] = ScopeChatWrite
+
+	handler := NewOAuthHandler(NewClient(), nil, WithOAuthScopes(scopes...))
+
+	// Appending to the caller's slice doesn't affect scopes of the handler.
+	_ = append(scopes, ScopeChannelRead)
+
+	assert.Equal(t, []OAuthScope{ScopeChatWrite, ScopeUserRead}, handler.scopes)
+}
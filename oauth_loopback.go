@@ -0,0 +1,225 @@
+package kicksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/glichtv/kick-sdk/optional"
+)
+
+const (
+	defaultLoopbackPath    = "/callback"
+	defaultLoopbackTimeout = 5 * time.Minute
+
+	loopbackShutdownTimeout = 5 * time.Second
+	loopbackHost            = "127.0.0.1"
+)
+
+var ErrAuthorizationTimeout = errors.New("authorization is not completed in time")
+
+type LoopbackAuthorizationInput struct {
+	Scopes []OAuthScope
+	// Port is a port of the loopback listener. Random free port is used if it's zero, but keep in mind
+	// that redirect URI with the exact port must be allowed in the application settings otherwise.
+	Port int
+	// Path is a path of the redirect URI, "/callback" by default.
+	Path string
+	// Timeout is a maximum time to wait for the user to complete authorization, 5 minutes by default.
+	Timeout time.Duration
+	// OpenURL is called with the authorization URL that must be shown to the user. By default, URL
+	// is printed to the standard output.
+	OpenURL func(authURL string) error
+}
+
+type loopbackResult struct {
+	code  string
+	state string
+	err   error
+	// outcome receives result of the authorization, so the user is told whether it succeeded only once
+	// the code is exchanged.
+	outcome chan<- error
+}
+
+// AuthorizeLoopback completes authorization code grant flow for the native applications (like CLI or desktop
+// tools) by receiving the redirect on the temporary loopback listener. Redirect URI of the client's credentials
+// is replaced with the listener's address.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc8252#section-7.3
+func (o OAuthResource) AuthorizeLoopback(
+	ctx context.Context,
+	input LoopbackAuthorizationInput,
+) (Response[AccessToken], error) {
+	if len(input.Path) == 0 {
+		input.Path = defaultLoopbackPath
+	}
+
+	if input.Timeout <= 0 {
+		input.Timeout = defaultLoopbackTimeout
+	}
+
+	if input.OpenURL == nil {
+		input.OpenURL = PrintAuthorizationURL
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(loopbackHost, strconv.Itoa(input.Port)))
+	if err != nil {
+		return Response[AccessToken]{}, fmt.Errorf("listen loopback: %w", err)
+	}
+
+	// Copy of the client is used to not affect the original client's redirect URI.
+	client := *o.client
+	client.credentials.RedirectURI = fmt.Sprintf("http://%s%s", listener.Addr().String(), input.Path)
+
+	session, err := client.OAuth().StartAuthorization(StartAuthorizationInput{Scopes: input.Scopes})
+	if err != nil {
+		_ = listener.Close()
+		return Response[AccessToken]{}, fmt.Errorf("start authorization: %w", err)
+	}
+
+	var (
+		results = make(chan loopbackResult, 1)
+		stop    = make(chan struct{})
+	)
+
+	server := &http.Server{
+		Handler:           newLoopbackHandler(input.Path, session.State, results, stop),
+		ReadHeaderTimeout: loopbackShutdownTimeout,
+	}
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loopbackShutdownTimeout)
+		defer cancel()
+
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	// Stop is closed before the server is shut down, so handlers waiting for the outcome are released.
+	defer close(stop)
+
+	if err = input.OpenURL(session.URL); err != nil {
+		return Response[AccessToken]{}, fmt.Errorf("open authorization URL: %w", err)
+	}
+
+	timer := time.NewTimer(input.Timeout)
+	defer timer.Stop()
+
+	var result loopbackResult
+
+	select {
+	case <-ctx.Done():
+		return Response[AccessToken]{}, ctx.Err()
+	case <-timer.C:
+		return Response[AccessToken]{}, ErrAuthorizationTimeout
+	case result = <-results:
+	}
+
+	if result.err != nil {
+		result.outcome <- result.err
+		return Response[AccessToken]{}, result.err
+	}
+
+	response, err := client.OAuth().ExchangeCode(ctx, ExchangeCodeInput{
+		Code:    result.code,
+		Session: optional.From(session),
+		State:   result.state,
+	})
+
+	result.outcome <- err
+
+	return response, err
+}
+
+// newLoopbackHandler creates handler of the redirect. Requests with unexpected state (e.g. sent by other local
+// processes) are rejected without affecting the authorization, which waits for the redirect of the user.
+func newLoopbackHandler(path, state string, results chan<- loopbackResult, stop <-chan struct{}) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+
+		if query.Get("state") != state {
+			http.Error(w, "Invalid state", http.StatusBadRequest)
+			return
+		}
+
+		var (
+			outcome = make(chan error, 1)
+			result  = loopbackResult{
+				code:    query.Get("code"),
+				state:   query.Get("state"),
+				outcome: outcome,
+			}
+		)
+
+		switch {
+		case len(query.Get("error")) != 0:
+			result.err = OAuthError{
+				Code:        query.Get("error"),
+				Description: query.Get("error_description"),
+			}
+		case len(result.code) == 0:
+			result.err = ErrNoAuthorizationCode
+		}
+
+		// Only the first redirect is accepted, the following ones are rejected.
+		select {
+		case results <- result:
+		default:
+			http.Error(w, "Authorization is already in progress", http.StatusConflict)
+			return
+		}
+
+		var err error
+
+		select {
+		case err = <-outcome:
+		case <-stop:
+			err = ErrAuthorizationTimeout
+		case <-request.Context().Done():
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "Authorization failed, you can close this window.", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte("Authorization completed, you can close this window."))
+	})
+
+	return mux
+}
+
+// PrintAuthorizationURL prints authorization URL to the standard output asking user to open it.
+func PrintAuthorizationURL(authURL string) error {
+	_, err := fmt.Fprintf(os.Stdout, "Open the following URL in your browser to authorize:\n\n%s\n\n", authURL)
+	return err
+}
+
+// OpenAuthorizationURL opens authorization URL in the default browser of the user.
+func OpenAuthorizationURL(authURL string) error {
+	var command *exec.Cmd
+
+	switch runtime.GOOS {
+	case "windows":
+		command = exec.Command("rundll32", "url.dll,FileProtocolHandler", authURL)
+	case "darwin":
+		command = exec.Command("open", authURL)
+	default:
+		command = exec.Command("xdg-open", authURL)
+	}
+
+	return command.Start()
+}
@@ -0,0 +1,155 @@
+package kicksdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redirectLoopback simulates user's browser being redirected back to the loopback listener. Status codes
+// of the responses to the redirects are sent to the channel if it's not nil.
+func redirectLoopback(
+	t *testing.T,
+	statusCodes chan<- int,
+	queries ...func(authQuery url.Values) url.Values,
+) func(string) error {
+	t.Helper()
+
+	return func(authURL string) error {
+		parsedURL, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+
+		redirectURI := parsedURL.Query().Get("redirect_uri")
+
+		if !strings.HasPrefix(redirectURI, "http://127.0.0.1:") {
+			return errors.New("unexpected redirect URI")
+		}
+
+		// Redirect is done in the background, since its response is sent only once authorization is completed.
+		go func() {
+			for _, query := range queries {
+				response, err := http.Get(redirectURI + "?" + query(parsedURL.Query()).Encode())
+				if err != nil {
+					return
+				}
+
+				_ = response.Body.Close()
+
+				if statusCodes != nil {
+					statusCodes <- response.StatusCode
+				}
+			}
+		}()
+
+		return nil
+	}
+}
+
+func TestOAuthResource_AuthorizeLoopback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Successful authorization", func(t *testing.T) {
+		var (
+			client      = newMockOAuthServer(t)
+			statusCodes = make(chan int, 1)
+		)
+
+		response, err := client.OAuth().AuthorizeLoopback(context.Background(), LoopbackAuthorizationInput{
+			Scopes: []OAuthScope{ScopeUserRead},
+			OpenURL: redirectLoopback(t, statusCodes, func(authQuery url.Values) url.Values {
+				return url.Values{
+					"code":  {"code"},
+					"state": {authQuery.Get("state")},
+				}
+			}),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "access-token", response.Payload.AccessToken)
+		assert.Equal(t, "http://localhost/callback", client.Credentials().RedirectURI)
+		assert.Equal(t, http.StatusOK, <-statusCodes)
+	})
+
+	t.Run("Rejected code", func(t *testing.T) {
+		var (
+			client      = newMockOAuthServer(t)
+			statusCodes = make(chan int, 1)
+		)
+
+		_, err := client.OAuth().AuthorizeLoopback(context.Background(), LoopbackAuthorizationInput{
+			OpenURL: redirectLoopback(t, statusCodes, func(authQuery url.Values) url.Values {
+				return url.Values{
+					"code":  {"invalid"},
+					"state": {authQuery.Get("state")},
+				}
+			}),
+		})
+		assert.ErrorIs(t, err, ErrInvalidGrant)
+
+		// User is told about the failure, since the code is exchanged before the response.
+		assert.Equal(t, http.StatusBadRequest, <-statusCodes)
+	})
+
+	t.Run("Authorization denied", func(t *testing.T) {
+		client := newMockOAuthServer(t)
+
+		_, err := client.OAuth().AuthorizeLoopback(context.Background(), LoopbackAuthorizationInput{
+			OpenURL: redirectLoopback(t, nil, func(authQuery url.Values) url.Values {
+				return url.Values{"error": {"access_denied"}, "state": {authQuery.Get("state")}}
+			}),
+		})
+
+		var oauthErr OAuthError
+
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, "access_denied", oauthErr.Code)
+	})
+
+	t.Run("Requests with forged state", func(t *testing.T) {
+		var (
+			client      = newMockOAuthServer(t)
+			statusCodes = make(chan int, 3)
+		)
+
+		response, err := client.OAuth().AuthorizeLoopback(context.Background(), LoopbackAuthorizationInput{
+			OpenURL: redirectLoopback(
+				t,
+				statusCodes,
+				func(url.Values) url.Values {
+					return url.Values{"code": {"code"}, "state": {"forged"}}
+				},
+				func(url.Values) url.Values {
+					return url.Values{}
+				},
+				func(authQuery url.Values) url.Values {
+					return url.Values{"code": {"code"}, "state": {authQuery.Get("state")}}
+				},
+			),
+		})
+
+		// Forged requests are rejected, but authorization still waits for the user's redirect.
+		assert.NoError(t, err)
+		assert.Equal(t, "access-token", response.Payload.AccessToken)
+		assert.Equal(t, http.StatusBadRequest, <-statusCodes)
+		assert.Equal(t, http.StatusBadRequest, <-statusCodes)
+		assert.Equal(t, http.StatusOK, <-statusCodes)
+	})
+
+	t.Run("Authorization timeout", func(t *testing.T) {
+		client := newMockOAuthServer(t)
+
+		_, err := client.OAuth().AuthorizeLoopback(context.Background(), LoopbackAuthorizationInput{
+			Timeout: 10 * time.Millisecond,
+			OpenURL: func(string) error { return nil },
+		})
+		assert.ErrorIs(t, err, ErrAuthorizationTimeout)
+	})
+}
@@ -0,0 +1,114 @@
+package kicksdk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/glichtv/kick-sdk/optional"
+)
+
+const (
+	// codeVerifierSize is a size of random bytes used for the code verifier, that results in 43
+	// characters verifier which is the minimum allowed by RFC 7636.
+	codeVerifierSize = 32
+	// stateSize is a size of random bytes used for the state.
+	stateSize = 16
+
+	CodeChallengeMethodS256 = "S256"
+)
+
+var ErrStateMismatch = errors.New("state does not match the authorization session")
+
+// AuthorizationSession is a data of the started authorization that must be persisted (e.g. in the
+// user's session) between redirecting user to the authorization page and exchanging the code.
+type AuthorizationSession struct {
+	State         string       `json:"state"`
+	CodeVerifier  string       `json:"code_verifier"`
+	CodeChallenge string       `json:"code_challenge"`
+	Scopes        []OAuthScope `json:"scopes"`
+	// URL is an authorization URL where user must be redirected.
+	URL string `json:"url"`
+}
+
+type StartAuthorizationInput struct {
+	Scopes []OAuthScope
+	// State is a state to use instead of the random one, e.g. signed with the StateSigner.
+	State optional.Optional[string]
+}
+
+// StartAuthorization generates PKCE code verifier, its S256 challenge and random state, and builds
+// authorization URL with them.
+//
+// Reference: https://docs.kick.com/getting-started/generating-tokens-oauth2-flow#authorization-endpoint
+func (o OAuthResource) StartAuthorization(input StartAuthorizationInput) (AuthorizationSession, error) {
+	codeVerifier, err := GenerateCodeVerifier()
+	if err != nil {
+		return AuthorizationSession{}, fmt.Errorf("generate code verifier: %w", err)
+	}
+
+	state, set := input.State.Value()
+	if !set {
+		if state, err = GenerateState(); err != nil {
+			return AuthorizationSession{}, fmt.Errorf("generate state: %w", err)
+		}
+	}
+
+	session := AuthorizationSession{
+		State:         state,
+		CodeVerifier:  codeVerifier,
+		CodeChallenge: CodeChallengeS256(codeVerifier),
+		Scopes:        input.Scopes,
+	}
+
+	session.URL = o.AuthorizationURL(AuthorizationURLInput{
+		ResponseType:  "code",
+		State:         session.State,
+		Scopes:        session.Scopes,
+		CodeChallenge: session.CodeChallenge,
+	})
+
+	return session, nil
+}
+
+// Verify verifies that the state returned to the redirect URI matches the session's state.
+func (s AuthorizationSession) Verify(state string) error {
+	if len(s.State) == 0 || subtle.ConstantTimeCompare([]byte(s.State), []byte(state)) != 1 {
+		return ErrStateMismatch
+	}
+
+	return nil
+}
+
+// GenerateCodeVerifier generates cryptographically random PKCE code verifier.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc7636#section-4.1
+func GenerateCodeVerifier() (string, error) {
+	return randomString(codeVerifierSize)
+}
+
+// CodeChallengeS256 computes PKCE code challenge of the verifier using S256 method.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func CodeChallengeS256(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// GenerateState generates cryptographically random state for the authorization request.
+func GenerateState() (string, error) {
+	return randomString(stateSize)
+}
+
+func randomString(size int) (string, error) {
+	buffer := make([]byte, size)
+
+	if _, err := rand.Read(buffer); err != nil {
+		return "", fmt.Errorf("read random bytes: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
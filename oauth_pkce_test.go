@@ -0,0 +1,102 @@
+package kicksdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/glichtv/kick-sdk/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeChallengeS256(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "qdgLLRr1saFHT6DWfWU28VNPIi7e9ynEBnBG3Oadw9g", CodeChallengeS256("code-verifier"))
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	t.Parallel()
+
+	first, err := GenerateCodeVerifier()
+	assert.NoError(t, err)
+
+	second, err := GenerateCodeVerifier()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+}
+
+func TestOAuthResource_StartAuthorization(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(
+		WithCredentials(Credentials{
+			ClientID:    "client-id",
+			RedirectURI: "redirect-uri",
+		}),
+	)
+
+	session, err := client.OAuth().StartAuthorization(StartAuthorizationInput{
+		Scopes: []OAuthScope{ScopeUserRead},
+	})
+	assert.NoError(t, err)
+
+	assert.NotEmpty(t, session.State)
+	assert.Equal(t, CodeChallengeS256(session.CodeVerifier), session.CodeChallenge)
+
+	authURL, err := url.Parse(session.URL)
+	assert.NoError(t, err)
+
+	query := authURL.Query()
+
+	assert.Equal(t, session.State, query.Get("state"))
+	assert.Equal(t, session.CodeChallenge, query.Get("code_challenge"))
+	assert.Equal(t, CodeChallengeMethodS256, query.Get("code_challenge_method"))
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "user:read", query.Get("scope"))
+}
+
+func TestOAuthResource_ExchangeCode_Session(t *testing.T) {
+	t.Parallel()
+
+	session := AuthorizationSession{
+		State:        "state",
+		CodeVerifier: "code-verifier",
+	}
+
+	t.Run("Matching state", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("code_verifier") != session.CodeVerifier {
+				http.Error(w, "Invalid code verifier", http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(AccessToken{AccessToken: "access-token"})
+		})
+
+		response, err := client.OAuth().ExchangeCode(context.Background(), ExchangeCodeInput{
+			Code:    "code",
+			Session: optional.From(session),
+			State:   "state",
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "access-token", response.Payload.AccessToken)
+	})
+
+	t.Run("Mismatching state", func(t *testing.T) {
+		client := NewClient()
+
+		_, err := client.OAuth().ExchangeCode(context.Background(), ExchangeCodeInput{
+			Code:    "code",
+			Session: optional.From(session),
+			State:   "forged-state",
+		})
+		assert.ErrorIs(t, err, ErrStateMismatch)
+	})
+}
@@ -0,0 +1,325 @@
+package kicksdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRefreshLeeway     = 5 * time.Minute
+	defaultRefreshJitter     = 30 * time.Second
+	defaultRefreshMinBackoff = time.Second
+	defaultRefreshMaxBackoff = 5 * time.Minute
+	// defaultTokenLifetime is a lifetime assumed for the tokens without expiration time.
+	defaultTokenLifetime = time.Hour
+	// minRefreshDelay is a minimum delay between refreshes, so misconfigured delays or tokens that are
+	// already expired once refreshed don't make refresher hammer the token endpoint.
+	minRefreshDelay = time.Second
+)
+
+var (
+	ErrRefresherClosed = errors.New("refresher is closed")
+	ErrNoRefreshToken  = errors.New("refresh token is not passed but required")
+)
+
+// RefresherEventType is a type of the event emitted by the Refresher.
+type RefresherEventType int
+
+const (
+	// RefresherEventRefreshed is emitted when token is successfully refreshed.
+	RefresherEventRefreshed RefresherEventType = iota + 1
+	// RefresherEventFailed is emitted when token refresh failed and will be retried.
+	RefresherEventFailed
+	// RefresherEventRevoked is emitted when refresh token is revoked or invalid, or when refresh
+	// failed permanently (e.g. client credentials are invalid), so token is no longer tracked and
+	// user must authorize again.
+	RefresherEventRevoked
+)
+
+type (
+	RefresherEvent struct {
+		Type RefresherEventType
+		// Key is a key that token is tracked with.
+		Key string
+		// Token is a refreshed token in case of RefresherEventRefreshed, and the last known token otherwise.
+		Token AccessToken
+		// Err is an error that caused RefresherEventFailed or RefresherEventRevoked.
+		Err error
+	}
+
+	RefresherCallback func(RefresherEvent)
+
+	// Refresher keeps access tokens warm by refreshing them ahead of their expiration.
+	Refresher struct {
+		client *Client
+
+		leeway     time.Duration
+		jitter     time.Duration
+		minBackoff time.Duration
+		maxBackoff time.Duration
+
+		callback RefresherCallback
+		events   chan<- RefresherEvent
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		tokens       map[string]*refresherEntry
+		tokensLocker sync.Mutex
+		closed       bool
+	}
+
+	refresherEntry struct {
+		token  AccessToken
+		cancel context.CancelFunc
+	}
+)
+
+// NewRefresher creates Refresher that refreshes tracked tokens until the context is canceled or
+// the Refresher is closed.
+func NewRefresher(ctx context.Context, client *Client, options ...RefresherOption) *Refresher {
+	refresher := &Refresher{
+		client:     client,
+		leeway:     defaultRefreshLeeway,
+		jitter:     defaultRefreshJitter,
+		minBackoff: defaultRefreshMinBackoff,
+		maxBackoff: defaultRefreshMaxBackoff,
+		tokens:     make(map[string]*refresherEntry),
+	}
+
+	refresher.ctx, refresher.cancel = context.WithCancel(ctx)
+
+	for _, option := range options {
+		option(refresher)
+	}
+
+	return refresher
+}
+
+// Track starts tracking the token with the provided key. If there is already a token tracked with
+// the same key, it is replaced.
+func (r *Refresher) Track(key string, token AccessToken) error {
+	if len(token.RefreshToken) == 0 {
+		return ErrNoRefreshToken
+	}
+
+	if token.ExpiresAt.IsZero() && token.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	r.tokensLocker.Lock()
+	defer r.tokensLocker.Unlock()
+
+	if r.closed || r.ctx.Err() != nil {
+		return ErrRefresherClosed
+	}
+
+	if existing, exist := r.tokens[key]; exist {
+		existing.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+
+	entry := &refresherEntry{
+		token:  token,
+		cancel: cancel,
+	}
+
+	r.tokens[key] = entry
+
+	r.wg.Add(1)
+	go r.keepWarm(ctx, key, entry)
+
+	return nil
+}
+
+// Untrack stops tracking the token with the provided key.
+func (r *Refresher) Untrack(key string) {
+	r.tokensLocker.Lock()
+	defer r.tokensLocker.Unlock()
+
+	if entry, exist := r.tokens[key]; exist {
+		entry.cancel()
+		delete(r.tokens, key)
+	}
+}
+
+// Token returns the latest token tracked with the provided key.
+func (r *Refresher) Token(key string) (AccessToken, bool) {
+	r.tokensLocker.Lock()
+	defer r.tokensLocker.Unlock()
+
+	entry, exist := r.tokens[key]
+	if !exist {
+		return AccessToken{}, false
+	}
+
+	return entry.token, true
+}
+
+// Close stops refreshing tokens and waits until all the in-flight refreshes are finished.
+func (r *Refresher) Close() error {
+	r.tokensLocker.Lock()
+	r.closed = true
+	r.tokensLocker.Unlock()
+
+	r.cancel()
+	r.wg.Wait()
+
+	return nil
+}
+
+func (r *Refresher) keepWarm(ctx context.Context, key string, entry *refresherEntry) {
+	defer r.wg.Done()
+
+	var (
+		token   = entry.token
+		delay   = r.refreshDelay(token)
+		attempt = 0
+	)
+
+	for {
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		refreshed, err := r.refresh(ctx, token)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err == nil:
+			token = refreshed
+			attempt = 0
+			delay = r.refreshDelay(token)
+
+			if !r.store(key, entry, token) {
+				return
+			}
+
+			r.emit(ctx, RefresherEvent{Type: RefresherEventRefreshed, Key: key, Token: token})
+		case !retryableRefreshError(err):
+			r.remove(key, entry)
+			r.emit(ctx, RefresherEvent{Type: RefresherEventRevoked, Key: key, Token: token, Err: err})
+
+			return
+		default:
+			delay = r.backoff(attempt)
+			attempt++
+
+			r.emit(ctx, RefresherEvent{Type: RefresherEventFailed, Key: key, Token: token, Err: err})
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context, token AccessToken) (AccessToken, error) {
+	response, err := r.client.OAuth().RefreshToken(ctx, RefreshTokenInput{
+		RefreshToken: token.RefreshToken,
+	})
+	if err != nil {
+		return AccessToken{}, fmt.Errorf("refresh token: %w", err)
+	}
+
+	refreshed := response.Payload
+
+	// Kick might not rotate refresh token, so the previous one is kept in this case.
+	if len(refreshed.RefreshToken) == 0 {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	return refreshed, nil
+}
+
+// store stores refreshed token if the entry is still tracked and returns false otherwise.
+func (r *Refresher) store(key string, entry *refresherEntry, token AccessToken) bool {
+	r.tokensLocker.Lock()
+	defer r.tokensLocker.Unlock()
+
+	if r.tokens[key] != entry {
+		return false
+	}
+
+	entry.token = token
+
+	return true
+}
+
+func (r *Refresher) remove(key string, entry *refresherEntry) {
+	r.tokensLocker.Lock()
+	defer r.tokensLocker.Unlock()
+
+	if r.tokens[key] == entry {
+		delete(r.tokens, key)
+	}
+}
+
+func (r *Refresher) emit(ctx context.Context, event RefresherEvent) {
+	if r.callback != nil {
+		r.callback(event)
+	}
+
+	if r.events != nil {
+		select {
+		case r.events <- event:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// retryableRefreshError reports whether refresh failed with the error that might go away on retry:
+// transport failures and temporary OAuth errors. Other OAuth errors (e.g. invalid_grant or
+// invalid_client) will fail the same way on every retry.
+func retryableRefreshError(err error) bool {
+	var oauthErr OAuthError
+	if errors.As(err, &oauthErr) {
+		return oauthErr.Temporary()
+	}
+
+	return true
+}
+
+// refreshDelay returns delay until the token must be refreshed: leeway and random jitter ahead
+// of the token's expiration. Token without expiration time is assumed to live for an hour.
+func (r *Refresher) refreshDelay(token AccessToken) time.Duration {
+	lifetime := time.Until(token.ExpiresAt)
+	if token.ExpiresAt.IsZero() {
+		lifetime = defaultTokenLifetime
+	}
+
+	delay := lifetime - r.leeway
+
+	if r.jitter > 0 {
+		delay -= rand.N(r.jitter)
+	}
+
+	return max(delay, minRefreshDelay)
+}
+
+// backoff returns exponential delay before the next retry.
+func (r *Refresher) backoff(attempt int) time.Duration {
+	delay := max(r.minBackoff, minRefreshDelay)
+
+	if delay >= r.maxBackoff {
+		return delay
+	}
+
+	for range attempt {
+		delay *= 2
+
+		if delay >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+
+	return delay
+}
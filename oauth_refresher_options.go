@@ -0,0 +1,43 @@
+package kicksdk
+
+import "time"
+
+type RefresherOption func(*Refresher)
+
+// WithRefreshLeeway sets how long before the expiration token is refreshed.
+func WithRefreshLeeway(leeway time.Duration) RefresherOption {
+	return func(refresher *Refresher) {
+		refresher.leeway = leeway
+	}
+}
+
+// WithRefreshJitter sets maximum random delay subtracted from the refresh time, so tokens issued at the
+// same time are not refreshed all at once.
+func WithRefreshJitter(jitter time.Duration) RefresherOption {
+	return func(refresher *Refresher) {
+		refresher.jitter = jitter
+	}
+}
+
+// WithRefreshBackoff sets minimum and maximum delays between retries of the failed refresh. Delays shorter
+// than a second are rounded up to it.
+func WithRefreshBackoff(minBackoff, maxBackoff time.Duration) RefresherOption {
+	return func(refresher *Refresher) {
+		refresher.minBackoff = minBackoff
+		refresher.maxBackoff = maxBackoff
+	}
+}
+
+func WithRefresherCallback(callback RefresherCallback) RefresherOption {
+	return func(refresher *Refresher) {
+		refresher.callback = callback
+	}
+}
+
+// WithRefresherEvents sets channel where events are sent to. Refresher blocks until event is received,
+// so channel must be drained.
+func WithRefresherEvents(events chan<- RefresherEvent) RefresherOption {
+	return func(refresher *Refresher) {
+		refresher.events = events
+	}
+}
@@ -0,0 +1,244 @@
+package kicksdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receiveRefresherEvent(t *testing.T, events <-chan RefresherEvent) RefresherEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("refresher event is not received")
+	}
+
+	return RefresherEvent{}
+}
+
+func TestRefresher(t *testing.T) {
+	t.Parallel()
+
+	expiringToken := AccessToken{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		ExpiresAt:    time.Now().Add(10 * time.Millisecond),
+	}
+
+	t.Run("Token is refreshed ahead of expiration", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("refresh_token") != "refresh-token" {
+				http.Error(w, "Invalid refresh token", http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"access_token": "refreshed-token", "expires_in": 3600}`))
+		})
+
+		events := make(chan RefresherEvent)
+
+		refresher := NewRefresher(
+			context.Background(),
+			client,
+			WithRefreshLeeway(0),
+			WithRefreshJitter(0),
+			WithRefresherEvents(events),
+		)
+
+		err := refresher.Track("user", expiringToken)
+		assert.NoError(t, err)
+
+		event := receiveRefresherEvent(t, events)
+
+		assert.Equal(t, RefresherEventRefreshed, event.Type)
+		assert.Equal(t, "user", event.Key)
+		assert.Equal(t, "refreshed-token", event.Token.AccessToken)
+		assert.Equal(t, "refresh-token", event.Token.RefreshToken)
+
+		token, exist := refresher.Token("user")
+		assert.True(t, exist)
+		assert.Equal(t, event.Token, token)
+
+		assert.NoError(t, refresher.Close())
+		assert.ErrorIs(t, refresher.Track("user", expiringToken), ErrRefresherClosed)
+	})
+
+	t.Run("Failed refresh is retried", func(t *testing.T) {
+		var calls atomic.Int32
+
+		client := newMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error": "server_error"}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"access_token": "refreshed-token", "expires_in": 3600}`))
+		})
+
+		events := make(chan RefresherEvent)
+
+		refresher := NewRefresher(
+			context.Background(),
+			client,
+			WithRefreshLeeway(0),
+			WithRefreshJitter(0),
+			WithRefreshBackoff(0, 0),
+			WithRefresherEvents(events),
+		)
+		defer refresher.Close()
+
+		err := refresher.Track("user", expiringToken)
+		assert.NoError(t, err)
+
+		event := receiveRefresherEvent(t, events)
+		assert.Equal(t, RefresherEventFailed, event.Type)
+		assert.Error(t, event.Err)
+
+		event = receiveRefresherEvent(t, events)
+		assert.Equal(t, RefresherEventRefreshed, event.Type)
+	})
+
+	t.Run("Revoked token is untracked", func(t *testing.T) {
+		client := newMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+		})
+
+		events := make(chan RefresherEvent, 1)
+
+		refresher := NewRefresher(
+			context.Background(),
+			client,
+			WithRefreshLeeway(0),
+			WithRefreshJitter(0),
+			WithRefresherCallback(func(event RefresherEvent) {
+				events <- event
+			}),
+		)
+		defer refresher.Close()
+
+		err := refresher.Track("user", expiringToken)
+		assert.NoError(t, err)
+
+		event := receiveRefresherEvent(t, events)
+		assert.Equal(t, RefresherEventRevoked, event.Type)
+
+		_, exist := refresher.Token("user")
+		assert.False(t, exist)
+	})
+
+	t.Run("Permanently failed refresh is not retried", func(t *testing.T) {
+		var calls atomic.Int32
+
+		client := newMockClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			calls.Add(1)
+
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+		})
+
+		events := make(chan RefresherEvent, 1)
+
+		refresher := NewRefresher(
+			context.Background(),
+			client,
+			WithRefreshLeeway(0),
+			WithRefreshJitter(0),
+			WithRefreshBackoff(0, 0),
+			WithRefresherEvents(events),
+		)
+		defer refresher.Close()
+
+		err := refresher.Track("user", expiringToken)
+		assert.NoError(t, err)
+
+		event := receiveRefresherEvent(t, events)
+		assert.Equal(t, RefresherEventRevoked, event.Type)
+		assert.ErrorIs(t, event.Err, ErrInvalidClient)
+
+		_, exist := refresher.Token("user")
+		assert.False(t, exist)
+
+		assert.NoError(t, refresher.Close())
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Token without refresh token", func(t *testing.T) {
+		refresher := NewRefresher(context.Background(), NewClient())
+		defer refresher.Close()
+
+		err := refresher.Track("user", AccessToken{AccessToken: "access-token"})
+		assert.ErrorIs(t, err, ErrNoRefreshToken)
+	})
+
+	t.Run("Refresher is stopped on context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		refresher := NewRefresher(ctx, NewClient())
+
+		err := refresher.Track("user", AccessToken{
+			RefreshToken: "refresh-token",
+			ExpiresAt:    time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+
+		cancel()
+
+		assert.ErrorIs(t, refresher.Track("user", expiringToken), ErrRefresherClosed)
+		assert.NoError(t, refresher.Close())
+	})
+}
+
+func TestRefresher_Backoff(t *testing.T) {
+	t.Parallel()
+
+	refresher := NewRefresher(
+		context.Background(),
+		NewClient(),
+		WithRefreshBackoff(time.Second, 5*time.Second),
+	)
+
+	assert.Equal(t, time.Second, refresher.backoff(0))
+	assert.Equal(t, 2*time.Second, refresher.backoff(1))
+	assert.Equal(t, 4*time.Second, refresher.backoff(2))
+	assert.Equal(t, 5*time.Second, refresher.backoff(3))
+	assert.Equal(t, 5*time.Second, refresher.backoff(42))
+}
+
+func TestRefresher_RefreshDelay(t *testing.T) {
+	t.Parallel()
+
+	refresher := NewRefresher(context.Background(), NewClient(), WithRefreshLeeway(time.Minute), WithRefreshJitter(0))
+
+	t.Run("Token with expiration time", func(t *testing.T) {
+		delay := refresher.refreshDelay(AccessToken{ExpiresAt: time.Now().Add(time.Hour)})
+
+		assert.InDelta(t, 59*time.Minute, delay, float64(time.Second))
+	})
+
+	t.Run("Token without expiration time", func(t *testing.T) {
+		assert.Equal(t, defaultTokenLifetime-time.Minute, refresher.refreshDelay(AccessToken{}))
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		delay := refresher.refreshDelay(AccessToken{ExpiresAt: time.Now().Add(-time.Hour)})
+
+		assert.Equal(t, minRefreshDelay, delay)
+	})
+
+	t.Run("Zero backoff", func(t *testing.T) {
+		refresher := NewRefresher(context.Background(), NewClient(), WithRefreshBackoff(0, 0))
+
+		assert.Equal(t, minRefreshDelay, refresher.backoff(0))
+		assert.Equal(t, minRefreshDelay, refresher.backoff(42))
+	})
+}
@@ -0,0 +1,185 @@
+package kicksdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultStateMaxAge = 10 * time.Minute
+	// MinStateSecretSize is a minimum size of the secret key used to sign states.
+	MinStateSecretSize = 32
+	// stateClockSkew is a maximum time that state might be issued in the future, so states signed by
+	// instances with slightly different clocks are still accepted.
+	stateClockSkew = time.Minute
+)
+
+var (
+	ErrInvalidState         = errors.New("state is malformed or its signature is invalid")
+	ErrStateExpired         = errors.New("state is expired")
+	ErrStateBindingMismatch = errors.New("state is bound to another browser")
+	ErrStateSecretTooShort  = errors.New("state secret is too short")
+)
+
+type (
+	// StateClaims are claims packed into the signed state.
+	StateClaims struct {
+		// Nonce is a random value that makes every state unique.
+		Nonce string
+		// IssuedAt is a time when state was signed.
+		IssuedAt time.Time
+		// ReturnTo is a URL where user should be returned after authorization. It is not validated by the
+		// StateSigner, so make sure it's a trusted URL before redirecting user to it.
+		ReturnTo string
+		// Claims are arbitrary application-specific claims.
+		Claims map[string]string
+	}
+
+	SignStateInput struct {
+		ReturnTo string
+		Claims   map[string]string
+		// Binding is a value bound to the user's browser, e.g. session ID or random value stored in the
+		// cookie. It must be passed to the Verify as well, so state issued to one browser can't be used
+		// by another one to complete authorization with the attacker's account (login CSRF). Binding is
+		// not stored in the state, only its MAC is.
+		Binding string
+	}
+
+	VerifyStateInput struct {
+		State string
+		// Binding is a value bound to the browser that OAuth callback is received from. It must match
+		// SignStateInput.Binding that state is signed with.
+		Binding string
+	}
+
+	stateClaimsPayload struct {
+		Nonce    string            `json:"n"`
+		IssuedAt int64             `json:"iat"`
+		ReturnTo string            `json:"rt,omitempty"`
+		Claims   map[string]string `json:"c,omitempty"`
+		Binding  string            `json:"b,omitempty"`
+	}
+
+	// StateSigner encodes claims into the HMAC-signed URL-safe state and verifies it, so OAuth callbacks can
+	// be validated without server-side storage of the states. Signature alone doesn't tie state to the
+	// browser that started authorization, so states should be signed with the binding.
+	StateSigner struct {
+		secret []byte
+		maxAge time.Duration
+		now    func() time.Time
+	}
+)
+
+// NewStateSigner creates StateSigner with the secret key used for signing. The key must be at least
+// MinStateSecretSize bytes long, kept private and shared between all instances that handle OAuth callbacks.
+func NewStateSigner(secret []byte, options ...StateSignerOption) (*StateSigner, error) {
+	if len(secret) < MinStateSecretSize {
+		return nil, ErrStateSecretTooShort
+	}
+
+	signer := &StateSigner{
+		secret: secret,
+		maxAge: defaultStateMaxAge,
+		now:    time.Now,
+	}
+
+	for _, option := range options {
+		option(signer)
+	}
+
+	return signer, nil
+}
+
+// Sign packs claims along with the random nonce, issue time and the binding's MAC into the signed state.
+func (ss *StateSigner) Sign(input SignStateInput) (string, error) {
+	nonce, err := GenerateState()
+	if err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+
+	payload, err := json.Marshal(stateClaimsPayload{
+		Nonce:    nonce,
+		IssuedAt: ss.now().Unix(),
+		ReturnTo: input.ReturnTo,
+		Claims:   input.Claims,
+		Binding:  ss.bindingMAC(input.Binding),
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal claims: %w", err)
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	signature := base64.RawURLEncoding.EncodeToString(ss.sign(encodedPayload))
+
+	return encodedPayload + "." + signature, nil
+}
+
+// Verify verifies signature, age and binding of the state and returns its claims.
+func (ss *StateSigner) Verify(input VerifyStateInput) (StateClaims, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(input.State, ".")
+	if !found {
+		return StateClaims{}, ErrInvalidState
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, ss.sign(encodedPayload)) {
+		return StateClaims{}, ErrInvalidState
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return StateClaims{}, ErrInvalidState
+	}
+
+	var payload stateClaimsPayload
+
+	if err = json.Unmarshal(payloadBytes, &payload); err != nil {
+		return StateClaims{}, ErrInvalidState
+	}
+
+	claims := StateClaims{
+		Nonce:    payload.Nonce,
+		IssuedAt: time.Unix(payload.IssuedAt, 0),
+		ReturnTo: payload.ReturnTo,
+		Claims:   payload.Claims,
+	}
+
+	now := ss.now()
+
+	if claims.IssuedAt.After(now.Add(stateClockSkew)) {
+		return StateClaims{}, ErrInvalidState
+	}
+
+	if ss.maxAge > 0 && now.Sub(claims.IssuedAt) > ss.maxAge {
+		return StateClaims{}, ErrStateExpired
+	}
+
+	if !hmac.Equal([]byte(payload.Binding), []byte(ss.bindingMAC(input.Binding))) {
+		return StateClaims{}, ErrStateBindingMismatch
+	}
+
+	return claims, nil
+}
+
+// bindingMAC returns encoded MAC of the binding, or empty string if there is no binding.
+func (ss *StateSigner) bindingMAC(binding string) string {
+	if len(binding) == 0 {
+		return ""
+	}
+
+	// Prefix separates binding MACs from the state signatures made with the same secret.
+	return base64.RawURLEncoding.EncodeToString(ss.sign("binding:" + binding))
+}
+
+func (ss *StateSigner) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, ss.secret)
+	_, _ = mac.Write([]byte(encodedPayload))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,20 @@
+package kicksdk
+
+import "time"
+
+type StateSignerOption func(*StateSigner)
+
+// WithStateMaxAge sets maximum age of the state, after which it's considered expired. Age is not
+// checked if it's not positive.
+func WithStateMaxAge(maxAge time.Duration) StateSignerOption {
+	return func(signer *StateSigner) {
+		signer.maxAge = maxAge
+	}
+}
+
+// WithStateClock sets function that returns current time.
+func WithStateClock(now func() time.Time) StateSignerOption {
+	return func(signer *StateSigner) {
+		signer.now = now
+	}
+}
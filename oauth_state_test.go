@@ -0,0 +1,133 @@
+package kicksdk
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glichtv/kick-sdk/optional"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSigner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now    = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		secret = []byte(strings.Repeat("s", MinStateSecretSize))
+	)
+
+	signer, err := NewStateSigner(
+		secret,
+		WithStateMaxAge(time.Minute),
+		WithStateClock(func() time.Time { return now }),
+	)
+	assert.NoError(t, err)
+
+	state, err := signer.Sign(SignStateInput{
+		ReturnTo: "/dashboard",
+		Claims:   map[string]string{"app": "bot"},
+		Binding:  "session",
+	})
+	assert.NoError(t, err)
+
+	t.Run("Valid state", func(t *testing.T) {
+		claims, err := signer.Verify(VerifyStateInput{State: state, Binding: "session"})
+		assert.NoError(t, err)
+
+		assert.NotEmpty(t, claims.Nonce)
+		assert.True(t, now.Equal(claims.IssuedAt))
+		assert.Equal(t, "/dashboard", claims.ReturnTo)
+		assert.Equal(t, map[string]string{"app": "bot"}, claims.Claims)
+	})
+
+	t.Run("State signed with another secret", func(t *testing.T) {
+		anotherSigner, err := NewStateSigner([]byte(strings.Repeat("a", MinStateSecretSize)))
+		assert.NoError(t, err)
+
+		_, err = anotherSigner.Verify(VerifyStateInput{State: state, Binding: "session"})
+		assert.ErrorIs(t, err, ErrInvalidState)
+	})
+
+	t.Run("Tampered state", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(state, ".")
+
+		_, err := signer.Verify(VerifyStateInput{State: payload + "x." + signature, Binding: "session"})
+		assert.ErrorIs(t, err, ErrInvalidState)
+
+		_, err = signer.Verify(VerifyStateInput{State: payload, Binding: "session"})
+		assert.ErrorIs(t, err, ErrInvalidState)
+	})
+
+	t.Run("State bound to another browser", func(t *testing.T) {
+		_, err := signer.Verify(VerifyStateInput{State: state, Binding: "another-session"})
+		assert.ErrorIs(t, err, ErrStateBindingMismatch)
+
+		_, err = signer.Verify(VerifyStateInput{State: state})
+		assert.ErrorIs(t, err, ErrStateBindingMismatch)
+
+		unboundState, err := signer.Sign(SignStateInput{})
+		assert.NoError(t, err)
+
+		_, err = signer.Verify(VerifyStateInput{State: unboundState, Binding: "session"})
+		assert.ErrorIs(t, err, ErrStateBindingMismatch)
+	})
+
+	t.Run("Binding is not exposed", func(t *testing.T) {
+		payload, _, _ := strings.Cut(state, ".")
+
+		decoded, err := base64.RawURLEncoding.DecodeString(payload)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(decoded), "session")
+	})
+
+	t.Run("Expired state", func(t *testing.T) {
+		laterSigner, err := NewStateSigner(
+			secret,
+			WithStateMaxAge(time.Minute),
+			WithStateClock(func() time.Time { return now.Add(2 * time.Minute) }),
+		)
+		assert.NoError(t, err)
+
+		_, err = laterSigner.Verify(VerifyStateInput{State: state, Binding: "session"})
+		assert.ErrorIs(t, err, ErrStateExpired)
+	})
+
+	t.Run("State issued in the future", func(t *testing.T) {
+		earlierSigner, err := NewStateSigner(
+			secret,
+			WithStateClock(func() time.Time { return now.Add(-stateClockSkew - time.Second) }),
+		)
+		assert.NoError(t, err)
+
+		_, err = earlierSigner.Verify(VerifyStateInput{State: state, Binding: "session"})
+		assert.ErrorIs(t, err, ErrInvalidState)
+
+		skewedSigner, err := NewStateSigner(
+			secret,
+			WithStateClock(func() time.Time { return now.Add(-stateClockSkew) }),
+		)
+		assert.NoError(t, err)
+
+		_, err = skewedSigner.Verify(VerifyStateInput{State: state, Binding: "session"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Short secret", func(t *testing.T) {
+		_, err := NewStateSigner(nil)
+		assert.ErrorIs(t, err, ErrStateSecretTooShort)
+
+		_, err = NewStateSigner([]byte("secret"))
+		assert.ErrorIs(t, err, ErrStateSecretTooShort)
+	})
+
+	t.Run("State used for authorization", func(t *testing.T) {
+		session, err := NewClient().OAuth().StartAuthorization(StartAuthorizationInput{
+			State: optional.From(state),
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, state, session.State)
+	})
+}
@@ -0,0 +1,78 @@
+package kicksdk
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScopes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, NewScopeSet(ScopeUserRead, ScopeChatWrite), ParseScopes("user:read  chat:write"))
+	assert.Equal(t, ScopeSet{}, ParseScopes(""))
+	assert.Equal(t, []OAuthScope{ScopeUserRead, ScopeChatWrite}, ParseScopesList("user:read chat:write"))
+}
+
+func TestScopeSet(t *testing.T) {
+	t.Parallel()
+
+	var (
+		granted  = NewScopeSet(ScopeUserRead, ScopeChatWrite)
+		required = NewScopeSet(ScopeChatWrite, ScopeChannelWrite)
+	)
+
+	assert.True(t, granted.Has(ScopeUserRead, ScopeChatWrite))
+	assert.False(t, granted.Has(ScopeUserRead, ScopeChannelWrite))
+
+	assert.Equal(t, []OAuthScope{ScopeChannelWrite, ScopeStreamKeyRead}, granted.Missing(
+		ScopeChannelWrite,
+		ScopeChatWrite,
+		ScopeStreamKeyRead,
+	))
+	assert.Nil(t, granted.Missing(ScopeUserRead))
+
+	assert.Equal(t, NewScopeSet(ScopeUserRead, ScopeChatWrite, ScopeChannelWrite), granted.Union(required))
+	assert.Equal(t, "chat:write user:read", granted.String())
+
+	var nilSet ScopeSet
+
+	assert.False(t, nilSet.Has(ScopeUserRead))
+	assert.True(t, nilSet.Has())
+}
+
+func TestScopeSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	scopeSetBytes, err := json.Marshal(NewScopeSet(ScopeUserRead, ScopeChatWrite))
+	assert.NoError(t, err)
+	assert.Equal(t, `["chat:write","user:read"]`, string(scopeSetBytes))
+
+	var scopeSet ScopeSet
+
+	err = json.Unmarshal(scopeSetBytes, &scopeSet)
+	assert.NoError(t, err)
+	assert.Equal(t, NewScopeSet(ScopeUserRead, ScopeChatWrite), scopeSet)
+
+	err = json.Unmarshal([]byte("null"), &scopeSet)
+	assert.NoError(t, err)
+	assert.Nil(t, scopeSet)
+}
+
+func TestMissingScopesError(t *testing.T) {
+	t.Parallel()
+
+	var err error = MissingScopesError{
+		Scopes: []OAuthScope{ScopeChannelWrite, ScopeChatWrite},
+	}
+
+	assert.ErrorIs(t, err, ErrMissingScope)
+	assert.Equal(t, "access token is missing required scopes: channel:write, chat:write", err.Error())
+
+	var missingErr MissingScopesError
+
+	assert.True(t, errors.As(err, &missingErr))
+	assert.Equal(t, []OAuthScope{ScopeChannelWrite, ScopeChatWrite}, missingErr.Scopes)
+}
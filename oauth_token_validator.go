@@ -0,0 +1,289 @@
+package kicksdk
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenCacheTTL  = time.Minute
+	defaultTokenCacheSize = 10_000
+	// inactiveTokenCacheSize is a maximum number of cached inactive tokens. They are cached separately,
+	// so arbitrary tokens sent by clients can't evict the valid ones.
+	inactiveTokenCacheSize = 1024
+)
+
+var (
+	ErrNoBearerToken      = errors.New("bearer token is not passed but required")
+	ErrInactiveToken      = errors.New("token is not active")
+	ErrExpiredToken       = errors.New("token is expired")
+	ErrClientIDMismatch   = errors.New("token is issued for another client")
+	ErrTokenIntrospection = errors.New("token can't be introspected")
+)
+
+type tokenInfoContextKey struct{}
+
+type (
+	// TokenErrorCallback is called when request is rejected by the TokenValidator's middleware.
+	TokenErrorCallback func(http.ResponseWriter, *http.Request, error)
+
+	// TokenValidator validates Kick's user access tokens passed to your own APIs by introspecting them.
+	// Results of the introspection are cached for a short time, so not every request hits Kick's API.
+	TokenValidator struct {
+		client    *Client
+		clientID  string
+		scopes    []OAuthScope
+		cacheTTL  time.Duration
+		cacheSize int
+		now       func() time.Time
+		onError   TokenErrorCallback
+
+		activeCache   *tokenCache
+		inactiveCache *tokenCache
+		cacheLocker   sync.Mutex
+	}
+
+	tokenCacheEntry struct {
+		key       [sha256.Size]byte
+		info      TokenInfo
+		err       error
+		expiresAt time.Time
+	}
+
+	// tokenCache is a bounded LRU cache of the introspection results, front of the list is the most recently
+	// used entry.
+	tokenCache struct {
+		capacity int
+		entries  map[[sha256.Size]byte]*list.Element
+		order    *list.List
+	}
+)
+
+// NewTokenValidator creates TokenValidator that requires tokens to be issued for the client ID of the
+// client's credentials (if it's set).
+func NewTokenValidator(client *Client, options ...TokenValidatorOption) *TokenValidator {
+	validator := &TokenValidator{
+		client:    client,
+		clientID:  client.credentials.ClientID,
+		cacheTTL:  defaultTokenCacheTTL,
+		cacheSize: defaultTokenCacheSize,
+		now:       time.Now,
+		onError:   defaultTokenErrorCallback,
+	}
+
+	for _, option := range options {
+		option(validator)
+	}
+
+	validator.activeCache = newTokenCache(validator.cacheSize)
+	validator.inactiveCache = newTokenCache(min(validator.cacheSize, inactiveTokenCacheSize))
+
+	return validator
+}
+
+// Middleware returns middleware that authenticates requests with the bearer token from the Authorization
+// header. TokenInfo of the valid token is stored in the request's context and can be retrieved with
+// TokenInfoFromContext.
+func (tv *TokenValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+		token, found := extractBearerToken(request)
+		if !found {
+			tv.onError(w, request, ErrNoBearerToken)
+			return
+		}
+
+		info, err := tv.Validate(request.Context(), token)
+		if err != nil {
+			tv.onError(w, request, err)
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), tokenInfoContextKey{}, info)
+
+		next.ServeHTTP(w, request.WithContext(ctx))
+	})
+}
+
+// Validate validates the token: it must be active, not expired, issued for the expected client and have
+// all the required scopes.
+func (tv *TokenValidator) Validate(ctx context.Context, token string) (TokenInfo, error) {
+	info, err := tv.introspect(ctx, token)
+	if err != nil {
+		return TokenInfo{}, err
+	}
+
+	if !info.Active {
+		return TokenInfo{}, ErrInactiveToken
+	}
+
+	if expiresAt := info.ExpiresAt(); !expiresAt.IsZero() && !tv.now().Before(expiresAt) {
+		return TokenInfo{}, ErrExpiredToken
+	}
+
+	if len(tv.clientID) != 0 && info.ClientID != tv.clientID {
+		return TokenInfo{}, ErrClientIDMismatch
+	}
+
+	if missing := info.Scopes().Missing(tv.scopes...); len(missing) != 0 {
+		return TokenInfo{}, MissingScopesError{Scopes: missing}
+	}
+
+	return info, nil
+}
+
+func (tv *TokenValidator) introspect(ctx context.Context, token string) (TokenInfo, error) {
+	var (
+		key = sha256.Sum256([]byte(token))
+		now = tv.now()
+	)
+
+	tv.cacheLocker.Lock()
+	entry, cached := tv.activeCache.get(key, now)
+	if !cached {
+		entry, cached = tv.inactiveCache.get(key, now)
+	}
+	tv.cacheLocker.Unlock()
+
+	if cached {
+		return entry.info, entry.err
+	}
+
+	response, err := tv.client.
+		WithAccessTokens(AccessTokens{UserAccessToken: token}).
+		Users().
+		IntrospectToken(ctx)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("%w: %w", ErrTokenIntrospection, err)
+	}
+
+	switch response.ResponseMetadata.StatusCode {
+	case http.StatusOK:
+		entry = tokenCacheEntry{key: key, info: response.Payload}
+	case http.StatusUnauthorized:
+		entry = tokenCacheEntry{key: key, err: ErrInactiveToken}
+	default:
+		return TokenInfo{}, fmt.Errorf(
+			"%w: unexpected status code %d",
+			ErrTokenIntrospection,
+			response.ResponseMetadata.StatusCode,
+		)
+	}
+
+	entry.expiresAt = now.Add(tv.cacheTTL)
+
+	// Token must not be considered valid from the cache after it's expired.
+	if expiresAt := entry.info.ExpiresAt(); !expiresAt.IsZero() && expiresAt.Before(entry.expiresAt) {
+		entry.expiresAt = expiresAt
+	}
+
+	tv.cacheLocker.Lock()
+	if entry.err == nil && entry.info.Active {
+		tv.activeCache.put(entry, now)
+	} else {
+		tv.inactiveCache.put(entry, now)
+	}
+	tv.cacheLocker.Unlock()
+
+	return entry.info, entry.err
+}
+
+func newTokenCache(capacity int) *tokenCache {
+	return &tokenCache{
+		capacity: capacity,
+		entries:  make(map[[sha256.Size]byte]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (tc *tokenCache) get(key [sha256.Size]byte, now time.Time) (tokenCacheEntry, bool) {
+	element, exist := tc.entries[key]
+	if !exist {
+		return tokenCacheEntry{}, false
+	}
+
+	entry := element.Value.(tokenCacheEntry)
+
+	if !now.Before(entry.expiresAt) {
+		tc.remove(element)
+		return tokenCacheEntry{}, false
+	}
+
+	tc.order.MoveToFront(element)
+
+	return entry, true
+}
+
+// put stores the entry and evicts the least recently used entries if the cache is full. Expired entries
+// at the back of the list are removed as well, so they don't wait for eviction.
+func (tc *tokenCache) put(entry tokenCacheEntry, now time.Time) {
+	if tc.capacity <= 0 {
+		return
+	}
+
+	if element, exist := tc.entries[entry.key]; exist {
+		tc.remove(element)
+	}
+
+	tc.entries[entry.key] = tc.order.PushFront(entry)
+
+	for element := tc.order.Back(); element != nil; element = tc.order.Back() {
+		if len(tc.entries) <= tc.capacity && now.Before(element.Value.(tokenCacheEntry).expiresAt) {
+			return
+		}
+
+		tc.remove(element)
+	}
+}
+
+func (tc *tokenCache) remove(element *list.Element) {
+	tc.order.Remove(element)
+	delete(tc.entries, element.Value.(tokenCacheEntry).key)
+}
+
+// TokenInfoFromContext returns TokenInfo stored in the context by the TokenValidator's middleware.
+func TokenInfoFromContext(ctx context.Context) (TokenInfo, bool) {
+	info, ok := ctx.Value(tokenInfoContextKey{}).(TokenInfo)
+	return info, ok
+}
+
+func extractBearerToken(request *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(request.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, len(token) != 0
+}
+
+// defaultTokenErrorCallback responds according to the RFC 6750.
+//
+// Reference: https://datatracker.ietf.org/doc/html/rfc6750#section-3
+func defaultTokenErrorCallback(w http.ResponseWriter, _ *http.Request, err error) {
+	var missingErr MissingScopesError
+
+	switch {
+	case errors.Is(err, ErrNoBearerToken):
+		w.Header().Set("WWW-Authenticate", `Bearer`)
+		http.Error(w, "Authorization is required", http.StatusUnauthorized)
+	case errors.As(err, &missingErr):
+		w.Header().Set(
+			"WWW-Authenticate",
+			fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, joinScopes(missingErr.Scopes, " ")),
+		)
+		http.Error(w, "Insufficient scope", http.StatusForbidden)
+	case errors.Is(err, ErrTokenIntrospection):
+		http.Error(w, "Cannot validate token", http.StatusServiceUnavailable)
+	default:
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+	}
+}
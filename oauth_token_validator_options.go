@@ -0,0 +1,47 @@
+package kicksdk
+
+import "time"
+
+type TokenValidatorOption func(*TokenValidator)
+
+// WithRequiredScopes sets scopes that tokens must be granted.
+func WithRequiredScopes(scopes ...OAuthScope) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.scopes = scopes
+	}
+}
+
+// WithExpectedClientID sets client ID that tokens must be issued for. Client ID is not checked if it's empty.
+func WithExpectedClientID(clientID string) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.clientID = clientID
+	}
+}
+
+// WithTokenCacheTTL sets for how long introspection results are cached.
+func WithTokenCacheTTL(ttl time.Duration) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.cacheTTL = ttl
+	}
+}
+
+// WithTokenCacheSize sets maximum number of cached introspection results, 10000 by default. Least recently
+// used results are evicted when it's exceeded. Results are not cached if it's not positive.
+func WithTokenCacheSize(size int) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.cacheSize = size
+	}
+}
+
+func WithTokenErrorCallback(onError TokenErrorCallback) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.onError = onError
+	}
+}
+
+// WithTokenValidatorClock sets function that returns current time.
+func WithTokenValidatorClock(now func() time.Time) TokenValidatorOption {
+	return func(validator *TokenValidator) {
+		validator.now = now
+	}
+}
@@ -0,0 +1,212 @@
+package kicksdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockIntrospectionClient(t *testing.T, calls *atomic.Int32) *Client {
+	t.Helper()
+
+	expires := time.Now().Add(time.Hour).Unix()
+
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid-token":
+			_, _ = fmt.Fprintf(
+				w,
+				`{"data": {"active": true, "client_id": "client-id", "exp": %d, "scope": "user:read"}}`,
+				expires,
+			)
+		case "Bearer foreign-token":
+			_, _ = fmt.Fprintf(w, `{"data": {"active": true, "client_id": "another-client-id", "exp": %d}}`, expires)
+		case "Bearer expired-token":
+			_, _ = w.Write([]byte(`{"data": {"active": true, "client_id": "client-id", "exp": 1}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "Unauthorized"}`))
+		}
+	})
+
+	client.credentials.ClientID = "client-id"
+
+	return client
+}
+
+func TestTokenValidator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	serve := func(validator *TokenValidator, authorization string) *httptest.ResponseRecorder {
+		var (
+			recorder = httptest.NewRecorder()
+			request  = httptest.NewRequest(http.MethodGet, "/", nil)
+		)
+
+		if len(authorization) != 0 {
+			request.Header.Set("Authorization", authorization)
+		}
+
+		handler := validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			info, ok := TokenInfoFromContext(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = w.Write([]byte(info.ClientID))
+		}))
+
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		var calls atomic.Int32
+
+		validator := NewTokenValidator(
+			newMockIntrospectionClient(t, &calls),
+			WithRequiredScopes(ScopeUserRead),
+		)
+
+		recorder := serve(validator, "Bearer valid-token")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "client-id", recorder.Body.String())
+
+		recorder = serve(validator, "bearer valid-token")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		var calls atomic.Int32
+
+		recorder := serve(NewTokenValidator(newMockIntrospectionClient(t, &calls)), "Basic credentials")
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("Invalid tokens", func(t *testing.T) {
+		var calls atomic.Int32
+
+		validator := NewTokenValidator(newMockIntrospectionClient(t, &calls))
+
+		for _, token := range []string{"invalid-token", "foreign-token", "expired-token"} {
+			recorder := serve(validator, "Bearer "+token)
+
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code, token)
+			assert.Equal(t, `Bearer error="invalid_token"`, recorder.Header().Get("WWW-Authenticate"), token)
+		}
+	})
+
+	t.Run("Token with insufficient scope", func(t *testing.T) {
+		var calls atomic.Int32
+
+		validator := NewTokenValidator(
+			newMockIntrospectionClient(t, &calls),
+			WithRequiredScopes(ScopeUserRead, ScopeChatWrite),
+		)
+
+		recorder := serve(validator, "Bearer valid-token")
+
+		assert.Equal(t, http.StatusForbidden, recorder.Code)
+		assert.Equal(
+			t,
+			`Bearer error="insufficient_scope", scope="chat:write"`,
+			recorder.Header().Get("WWW-Authenticate"),
+		)
+	})
+
+	t.Run("Cache expiration", func(t *testing.T) {
+		var (
+			calls atomic.Int32
+			now   = time.Now()
+		)
+
+		validator := NewTokenValidator(
+			newMockIntrospectionClient(t, &calls),
+			WithTokenCacheTTL(time.Minute),
+			WithTokenValidatorClock(func() time.Time { return now }),
+		)
+
+		_ = serve(validator, "Bearer invalid-token")
+		_ = serve(validator, "Bearer invalid-token")
+		assert.Equal(t, int32(1), calls.Load())
+
+		now = now.Add(2 * time.Minute)
+
+		_ = serve(validator, "Bearer invalid-token")
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
+
+func TestTokenValidator_Cache(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	validator := NewTokenValidator(newMockIntrospectionClient(t, &calls), WithTokenCacheSize(2*inactiveTokenCacheSize))
+
+	_, err := validator.Validate(context.Background(), "valid-token")
+	assert.NoError(t, err)
+
+	for i := range 2 * inactiveTokenCacheSize {
+		_, err = validator.Validate(context.Background(), "invalid-token-"+strconv.Itoa(i))
+		assert.ErrorIs(t, err, ErrInactiveToken)
+	}
+
+	assert.Len(t, validator.inactiveCache.entries, inactiveTokenCacheSize)
+	assert.Equal(t, inactiveTokenCacheSize, validator.inactiveCache.order.Len())
+
+	// Flood of inactive tokens doesn't evict the valid ones.
+	calls.Store(0)
+
+	_, err = validator.Validate(context.Background(), "valid-token")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), calls.Load())
+
+	t.Run("Least recently used token is evicted", func(t *testing.T) {
+		cache := newTokenCache(2)
+		now := time.Now()
+
+		for _, token := range []string{"first", "second"} {
+			cache.put(tokenCacheEntry{key: sha256.Sum256([]byte(token)), expiresAt: now.Add(time.Minute)}, now)
+		}
+
+		_, cached := cache.get(sha256.Sum256([]byte("first")), now)
+		assert.True(t, cached)
+
+		cache.put(tokenCacheEntry{key: sha256.Sum256([]byte("third")), expiresAt: now.Add(time.Minute)}, now)
+
+		_, cached = cache.get(sha256.Sum256([]byte("second")), now)
+		assert.False(t, cached)
+
+		_, cached = cache.get(sha256.Sum256([]byte("first")), now)
+		assert.True(t, cached)
+	})
+
+	t.Run("Expired token is removed", func(t *testing.T) {
+		cache := newTokenCache(2)
+		now := time.Now()
+
+		cache.put(tokenCacheEntry{key: sha256.Sum256([]byte("first")), expiresAt: now.Add(time.Minute)}, now)
+
+		_, cached := cache.get(sha256.Sum256([]byte("first")), now.Add(time.Minute))
+		assert.False(t, cached)
+		assert.Empty(t, cache.entries)
+	})
+}
@@ -0,0 +1,132 @@
+package kicksdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultScopeUpgradeTTL = 10 * time.Minute
+
+type ScopeUpgradeInput struct {
+	// Granted are scopes already granted to the user, e.g. AccessToken.Scopes.
+	Granted ScopeSet
+	// Required are scopes required by the feature.
+	Required []OAuthScope
+}
+
+// ScopeUpgrade is an authorization that requests scopes missing for a feature along with the already
+// granted ones, so user doesn't lose access that was granted before.
+type ScopeUpgrade struct {
+	// Session is an authorization session for the union of granted and required scopes.
+	Session AuthorizationSession
+	// Missing are required scopes that were not granted at the time upgrade was started.
+	Missing []OAuthScope
+}
+
+// UpgradeScopes starts authorization that requests scopes required by a feature in addition to the granted
+// ones. It returns false if all the required scopes are already granted and no upgrade is needed.
+func (o OAuthResource) UpgradeScopes(input ScopeUpgradeInput) (ScopeUpgrade, bool, error) {
+	missing := input.Granted.Missing(input.Required...)
+	if len(missing) == 0 {
+		return ScopeUpgrade{}, false, nil
+	}
+
+	scopes := input.Granted.Union(NewScopeSet(missing...))
+
+	session, err := o.StartAuthorization(StartAuthorizationInput{
+		Scopes: scopes.List(),
+	})
+	if err != nil {
+		return ScopeUpgrade{}, false, fmt.Errorf("start authorization: %w", err)
+	}
+
+	return ScopeUpgrade{
+		Session: session,
+		Missing: missing,
+	}, true, nil
+}
+
+// Verify verifies that the token issued as a result of the upgrade was granted all the requested scopes,
+// since user is able to deselect some of them. It returns MissingScopesError otherwise.
+func (su ScopeUpgrade) Verify(token AccessToken) error {
+	if missing := token.Scopes().Missing(su.Session.Scopes...); len(missing) != 0 {
+		return MissingScopesError{Scopes: missing}
+	}
+
+	return nil
+}
+
+type pendingScopeUpgrade struct {
+	upgrade   ScopeUpgrade
+	expiresAt time.Time
+}
+
+// PendingScopeUpgrades is a concurrency-safe in-memory storage of the scope upgrades that user has not
+// completed yet. Upgrades are keyed by the state of their authorization sessions.
+type PendingScopeUpgrades struct {
+	ttl time.Duration
+
+	upgrades       map[string]pendingScopeUpgrade
+	upgradesLocker sync.Mutex
+}
+
+// NewPendingScopeUpgrades creates PendingScopeUpgrades where upgrades expire after the TTL. Default TTL
+// of 10 minutes is used if it's not positive.
+func NewPendingScopeUpgrades(ttl time.Duration) *PendingScopeUpgrades {
+	if ttl <= 0 {
+		ttl = defaultScopeUpgradeTTL
+	}
+
+	return &PendingScopeUpgrades{
+		ttl:      ttl,
+		upgrades: make(map[string]pendingScopeUpgrade),
+	}
+}
+
+// Add starts tracking the upgrade until it's taken or expired.
+func (psu *PendingScopeUpgrades) Add(upgrade ScopeUpgrade) {
+	psu.upgradesLocker.Lock()
+	defer psu.upgradesLocker.Unlock()
+
+	now := time.Now()
+
+	// Expired upgrades are removed lazily, since they are never taken once user abandoned authorization.
+	for state, pending := range psu.upgrades {
+		if now.After(pending.expiresAt) {
+			delete(psu.upgrades, state)
+		}
+	}
+
+	psu.upgrades[upgrade.Session.State] = pendingScopeUpgrade{
+		upgrade:   upgrade,
+		expiresAt: now.Add(psu.ttl),
+	}
+}
+
+// Take returns the pending upgrade by the state returned to the redirect URI and stops tracking it.
+func (psu *PendingScopeUpgrades) Take(state string) (ScopeUpgrade, bool) {
+	psu.upgradesLocker.Lock()
+	defer psu.upgradesLocker.Unlock()
+
+	pending, exist := psu.upgrades[state]
+	if !exist {
+		return ScopeUpgrade{}, false
+	}
+
+	delete(psu.upgrades, state)
+
+	if time.Now().After(pending.expiresAt) {
+		return ScopeUpgrade{}, false
+	}
+
+	return pending.upgrade, true
+}
+
+// Len returns number of the pending upgrades.
+func (psu *PendingScopeUpgrades) Len() int {
+	psu.upgradesLocker.Lock()
+	defer psu.upgradesLocker.Unlock()
+
+	return len(psu.upgrades)
+}
@@ -0,0 +1,93 @@
+package kicksdk
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthResource_UpgradeScopes(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(
+		WithCredentials(Credentials{
+			ClientID:    "client-id",
+			RedirectURI: "redirect-uri",
+		}),
+	)
+
+	t.Run("Upgrade with missing scopes", func(t *testing.T) {
+		upgrade, needed, err := client.OAuth().UpgradeScopes(ScopeUpgradeInput{
+			Granted:  NewScopeSet(ScopeUserRead, ScopeChatWrite),
+			Required: []OAuthScope{ScopeChatWrite, ScopeChannelWrite},
+		})
+		assert.NoError(t, err)
+		assert.True(t, needed)
+
+		assert.Equal(t, []OAuthScope{ScopeChannelWrite}, upgrade.Missing)
+		assert.Equal(t, []OAuthScope{ScopeChannelWrite, ScopeChatWrite, ScopeUserRead}, upgrade.Session.Scopes)
+
+		authURL, err := url.Parse(upgrade.Session.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "channel:write chat:write user:read", authURL.Query().Get("scope"))
+	})
+
+	t.Run("Upgrade without missing scopes", func(t *testing.T) {
+		_, needed, err := client.OAuth().UpgradeScopes(ScopeUpgradeInput{
+			Granted:  NewScopeSet(ScopeUserRead, ScopeChatWrite),
+			Required: []OAuthScope{ScopeChatWrite},
+		})
+		assert.NoError(t, err)
+		assert.False(t, needed)
+	})
+}
+
+func TestScopeUpgrade_Verify(t *testing.T) {
+	t.Parallel()
+
+	upgrade := ScopeUpgrade{
+		Session: AuthorizationSession{
+			Scopes: []OAuthScope{ScopeUserRead, ScopeChannelWrite},
+		},
+	}
+
+	err := upgrade.Verify(AccessToken{Scope: "user:read channel:write"})
+	assert.NoError(t, err)
+
+	err = upgrade.Verify(AccessToken{Scope: "user:read"})
+	assert.ErrorIs(t, err, ErrMissingScope)
+	assert.Equal(t, MissingScopesError{Scopes: []OAuthScope{ScopeChannelWrite}}, err)
+}
+
+func TestPendingScopeUpgrades(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Take pending upgrade", func(t *testing.T) {
+		var (
+			pending = NewPendingScopeUpgrades(time.Minute)
+			upgrade = ScopeUpgrade{Session: AuthorizationSession{State: "state"}}
+		)
+
+		pending.Add(upgrade)
+		assert.Equal(t, 1, pending.Len())
+
+		takenUpgrade, exist := pending.Take("state")
+		assert.True(t, exist)
+		assert.Equal(t, upgrade, takenUpgrade)
+
+		_, exist = pending.Take("state")
+		assert.False(t, exist)
+	})
+
+	t.Run("Take expired upgrade", func(t *testing.T) {
+		pending := NewPendingScopeUpgrades(time.Nanosecond)
+		pending.Add(ScopeUpgrade{Session: AuthorizationSession{State: "state"}})
+
+		time.Sleep(time.Millisecond)
+
+		_, exist := pending.Take("state")
+		assert.False(t, exist)
+	})
+}